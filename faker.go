@@ -0,0 +1,35 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The word lists used by the generated faker functions.
+// They are written into the generated code, so seeding works offline.
+//
+//go:embed faker
+var wordLists embed.FS
+
+// wordList returns the words in faker/<name>.txt as the body of a Go string slice literal
+func wordList(name string) (string, error) {
+	contents, err := wordLists.ReadFile("faker/" + name + ".txt")
+	if err != nil {
+		return "", fmt.Errorf("unknown word list %q: %w", name, err)
+	}
+
+	var b strings.Builder
+	for _, word := range strings.Split(string(contents), "\n") {
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+
+		b.WriteString(strconv.Quote(word))
+		b.WriteString(",\n")
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestWordLists(t *testing.T) {
+	files, err := fs.Glob(wordLists, "faker/*.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("expected embedded word lists")
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "faker/"), ".txt")
+		list, err := wordList(name)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", name, err)
+		}
+		if !strings.HasPrefix(list, `"`) || strings.Contains(list, `"",`) {
+			t.Errorf("expected a list of quoted words for %s, got %q", name, list)
+		}
+	}
+
+	if _, err := wordList("missing"); err == nil {
+		t.Error("expected an error for a missing word list")
+	}
+}
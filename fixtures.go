@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+// fixtureFormats are the formats LoadFixtures reads on top of JSON.
+// Each of them adds a dependency to the generated seeder, so they are only
+// generated when listed in the --fixture-formats flag or the boilingseed.fixture_formats config list.
+var fixtureFormats map[string]bool
+
+// parseFixtureFormats reads the fixture formats, which can be json, yaml and toml
+func parseFixtureFormats(entries []string) (map[string]bool, error) {
+	formats := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		format := strings.ToLower(strings.TrimSpace(entry))
+		switch format {
+		case "json":
+			// always read
+		case "yaml", "yml":
+			formats["yaml"] = true
+		case "toml":
+			formats["toml"] = true
+		default:
+			return nil, fmt.Errorf("unknown fixture format %q, use json, yaml or toml", entry)
+		}
+	}
+
+	return formats, nil
+}
+
+// fixtureFormat reports whether LoadFixtures is generated with support for the format
+func fixtureFormat(format string) bool {
+	return fixtureFormats[format]
+}
+
+// fixtureRef is the foreign key a fixture column can reference another row through.
+// Parts has every column of the constraint, so a reference to a row sets all of them.
+type fixtureRef struct {
+	Column       string
+	ForeignTable string
+	Parts        []drivers.ForeignKey
+}
+
+// fixtureRefs returns the foreign key of each foreign key column of the table, sorted by column.
+// A column that is in more than one constraint references rows through the one
+// with the fewest columns, usually the foreign key of that column alone.
+func fixtureRefs(table drivers.Table) []fixtureRef {
+	refs := map[string]foreignKey{}
+	for _, fkey := range groupFKeys(table.FKeys) {
+		for _, part := range fkey.Parts {
+			if ref, ok := refs[part.Column]; !ok || len(fkey.Parts) < len(ref.Parts) {
+				refs[part.Column] = fkey
+			}
+		}
+	}
+
+	columns := make([]string, 0, len(refs))
+	for column := range refs {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	list := make([]fixtureRef, len(columns))
+	for i, column := range columns {
+		list[i] = fixtureRef{Column: column, ForeignTable: refs[column].ForeignTable, Parts: refs[column].Parts}
+	}
+
+	return list
+}
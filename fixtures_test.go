@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+func TestParseFixtureFormats(t *testing.T) {
+	formats, err := parseFixtureFormats([]string{"json", " YML ", "toml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(formats) != 2 || !formats["yaml"] || !formats["toml"] {
+		t.Errorf("unexpected fixture formats: %v", formats)
+	}
+
+	if _, err := parseFixtureFormats([]string{"xml"}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestFixtureRefs(t *testing.T) {
+	tasks := drivers.Table{Name: "tasks", FKeys: []drivers.ForeignKey{
+		{Table: "tasks", Name: "tasks_tenant_fkey", Column: "tenant_id", ForeignTable: "tenants", ForeignColumn: "id"},
+		{Table: "tasks", Name: "tasks_project_fkey", Column: "tenant_id", ForeignTable: "projects", ForeignColumn: "tenant_id"},
+		{Table: "tasks", Name: "tasks_project_fkey", Column: "project_id", ForeignTable: "projects", ForeignColumn: "id"},
+	}}
+
+	refs := fixtureRefs(tasks)
+	if len(refs) != 2 || refs[0].Column != "project_id" || refs[1].Column != "tenant_id" {
+		t.Fatalf("expected project_id and tenant_id, got %v", refs)
+	}
+
+	if refs[0].ForeignTable != "projects" || len(refs[0].Parts) != 2 {
+		t.Errorf("expected project_id to set both columns of the composite key, got %v", refs[0])
+	}
+	if refs[1].ForeignTable != "tenants" || len(refs[1].Parts) != 1 {
+		t.Errorf("expected tenant_id to reference tenants on its own, got %v", refs[1])
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aarondl/sqlboiler/v4/boilingcore"
+	"github.com/aarondl/sqlboiler/v4/drivers"
+	"github.com/aarondl/strmangle"
+)
+
+// foreignKey is a foreign key constraint.
+// The embedded ForeignKey is its first column, and Parts has every column
+// of the constraint, so composite keys can be set from a single parent row.
+// Association is set on the keys that make up the primary key of an association table.
+type foreignKey struct {
+	drivers.ForeignKey
+	Parts []drivers.ForeignKey
+
+	Association bool
+}
+
+// groupFKeys groups the foreign keys by constraint, in the order they are found.
+// A composite key is nullable if any of its columns is, since a NULL
+// in one of the columns is enough to satisfy the constraint.
+func groupFKeys(fkeys []drivers.ForeignKey) []foreignKey {
+	var groups []foreignKey
+	index := map[string]int{}
+
+	for _, fkey := range fkeys {
+		i, ok := index[fkeyID(fkey)]
+		if !ok {
+			index[fkeyID(fkey)] = len(groups)
+			groups = append(groups, foreignKey{ForeignKey: fkey, Parts: []drivers.ForeignKey{fkey}})
+			continue
+		}
+
+		groups[i].Parts = append(groups[i].Parts, fkey)
+		groups[i].Nullable = groups[i].Nullable || fkey.Nullable
+	}
+
+	return groups
+}
+
+// markAssociation flags the foreign keys of an association table: a table that is not
+// a sqlboiler join table, usually because it has payload columns or more than two
+// foreign keys, but whose primary key is made up of the columns of two or more of them.
+// Every row of such a table has to link a different combination of parents.
+func markAssociation(table drivers.Table, fkeys []foreignKey) {
+	if table.IsJoinTable || table.PKey == nil || len(table.PKey.Columns) < 2 {
+		return
+	}
+
+	covering := map[int]bool{}
+	for _, column := range table.PKey.Columns {
+		found := false
+		for i, fkey := range fkeys {
+			for _, part := range fkey.Parts {
+				if part.Column == column {
+					covering[i] = true
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			return
+		}
+	}
+
+	if len(covering) < 2 {
+		return
+	}
+
+	for i := range covering {
+		fkeys[i].Association = true
+	}
+}
+
+// compositeFKey is a composite foreign key declared in the [[boilingseed.foreign_keys]] config.
+// The drivers skip composite keys, so they have to be declared to be seeded.
+type compositeFKey struct {
+	name           string
+	table          string
+	columns        []string
+	foreignTable   string
+	foreignColumns []string
+}
+
+// parseCompositeFKeys reads the [[boilingseed.foreign_keys]] config section
+func parseCompositeFKeys(section interface{}) ([]compositeFKey, error) {
+	var entries []map[string]interface{}
+	switch section := section.(type) {
+	case nil:
+		return nil, nil
+	case []map[string]interface{}:
+		entries = section
+	case []interface{}:
+		for _, entry := range section {
+			entry, ok := entry.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("foreign keys must be tables, got %T", entry)
+			}
+			entries = append(entries, entry)
+		}
+	default:
+		return nil, fmt.Errorf("foreign keys must be an array of tables, got %T", section)
+	}
+
+	fkeys := make([]compositeFKey, len(entries))
+	for i, entry := range entries {
+		fkey := compositeFKey{
+			name:         fmt.Sprint(entry["name"]),
+			table:        fmt.Sprint(entry["table"]),
+			foreignTable: fmt.Sprint(entry["foreign_table"]),
+		}
+
+		if entry["name"] == nil || entry["table"] == nil || entry["foreign_table"] == nil {
+			return nil, fmt.Errorf("foreign key %d must have a name, a table and a foreign_table", i)
+		}
+
+		var err error
+		if fkey.columns, err = stringList(entry["columns"]); err != nil {
+			return nil, fmt.Errorf("foreign key %s: columns %w", fkey.name, err)
+		}
+		if fkey.foreignColumns, err = stringList(entry["foreign_columns"]); err != nil {
+			return nil, fmt.Errorf("foreign key %s: foreign_columns %w", fkey.name, err)
+		}
+
+		if len(fkey.columns) == 0 || len(fkey.columns) != len(fkey.foreignColumns) {
+			return nil, fmt.Errorf("foreign key %s must have as many columns as foreign_columns", fkey.name)
+		}
+
+		fkeys[i] = fkey
+	}
+
+	return fkeys, nil
+}
+
+func stringList(value interface{}) ([]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a list of strings, got %T", value)
+	}
+
+	list := make([]string, len(values))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a list of strings, got %T in it", v)
+		}
+		list[i] = s
+	}
+
+	return list, nil
+}
+
+// addCompositeFKeys adds the declared composite foreign keys to the tables,
+// as one ForeignKey per column sharing the name of the constraint
+func addCompositeFKeys(tables []drivers.Table, fkeys []compositeFKey) error {
+	for _, fkey := range fkeys {
+		i := tableIndex(tables, fkey.table)
+		j := tableIndex(tables, fkey.foreignTable)
+		if i < 0 || j < 0 {
+			return fmt.Errorf("foreign key %s: unknown table %s or %s", fkey.name, fkey.table, fkey.foreignTable)
+		}
+
+		for k, column := range fkey.columns {
+			local, ok := findColumn(tables[i], column)
+			if !ok {
+				return fmt.Errorf("foreign key %s: unknown column %s.%s", fkey.name, fkey.table, column)
+			}
+			foreign, ok := findColumn(tables[j], fkey.foreignColumns[k])
+			if !ok {
+				return fmt.Errorf("foreign key %s: unknown column %s.%s", fkey.name, fkey.foreignTable, fkey.foreignColumns[k])
+			}
+
+			tables[i].FKeys = append(tables[i].FKeys, drivers.ForeignKey{
+				Table:                 fkey.table,
+				Name:                  fkey.name,
+				Column:                local.Name,
+				Nullable:              local.Nullable,
+				Unique:                local.Unique,
+				ForeignTable:          fkey.foreignTable,
+				ForeignColumn:         foreign.Name,
+				ForeignColumnNullable: foreign.Nullable,
+				ForeignColumnUnique:   foreign.Unique,
+			})
+		}
+	}
+
+	return nil
+}
+
+// addCompositeAliases names the relationships of the declared composite foreign keys
+// that have no alias in the config. They are added to the tables after sqlboiler
+// fills its aliases, and it would name them after their first column anyway,
+// so they are named after the foreign table, or after the constraint
+// if the table already has a relationship with that name.
+func addCompositeAliases(aliases *boilingcore.Aliases, fkeys []compositeFKey) {
+	if aliases.Tables == nil {
+		aliases.Tables = make(map[string]boilingcore.TableAlias)
+	}
+
+	for _, fkey := range fkeys {
+		table := aliases.Tables[fkey.table]
+		if table.Relationships == nil {
+			table.Relationships = make(map[string]boilingcore.RelationshipAlias)
+		}
+
+		r := table.Relationships[fkey.name]
+		if r.Local == "" {
+			r.Local = strmangle.TitleCase(strmangle.Plural(fkey.table))
+		}
+		if r.Foreign == "" {
+			r.Foreign = strmangle.TitleCase(strmangle.Singular(fkey.foreignTable))
+			for name, other := range table.Relationships {
+				if name != fkey.name && other.Foreign == r.Foreign {
+					r.Foreign = strmangle.TitleCase(fkey.name)
+					break
+				}
+			}
+		}
+
+		table.Relationships[fkey.name] = r
+		aliases.Tables[fkey.table] = table
+	}
+}
+
+func tableIndex(tables []drivers.Table, name string) int {
+	for i, table := range tables {
+		if table.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func findColumn(table drivers.Table, name string) (drivers.Column, bool) {
+	for _, column := range table.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+
+	return drivers.Column{}, false
+}
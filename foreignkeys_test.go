@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aarondl/sqlboiler/v4/boilingcore"
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+func TestGroupFKeys(t *testing.T) {
+	groups := groupFKeys([]drivers.ForeignKey{
+		fkey("tasks", "owner_id", "users", false),
+		{Table: "tasks", Name: "tasks_project_fkey", Column: "tenant_id", ForeignTable: "projects", ForeignColumn: "tenant_id"},
+		{Table: "tasks", Name: "tasks_project_fkey", Column: "project_id", ForeignTable: "projects", ForeignColumn: "id", Nullable: true},
+	})
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 constraints, got %v", groups)
+	}
+	if len(groups[0].Parts) != 1 || groups[0].Nullable {
+		t.Errorf("expected owner_id on its own and NOT NULL, got %v", groups[0])
+	}
+
+	project := groups[1]
+	if project.Column != "tenant_id" || len(project.Parts) != 2 || project.Parts[1].Column != "project_id" {
+		t.Errorf("expected tenant_id and project_id in one constraint, got %v", project)
+	}
+	if !project.Nullable {
+		t.Error("expected the composite key to be nullable because project_id is")
+	}
+}
+
+func TestCompositeFKeys(t *testing.T) {
+	fkeys, err := parseCompositeFKeys([]interface{}{
+		map[string]interface{}{
+			"name":            "tasks_project_fkey",
+			"table":           "tasks",
+			"columns":         []interface{}{"tenant_id", "project_id"},
+			"foreign_table":   "projects",
+			"foreign_columns": []interface{}{"tenant_id", "id"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tables := []drivers.Table{
+		{Name: "projects", Columns: []drivers.Column{{Name: "tenant_id"}, {Name: "id"}}},
+		{Name: "tasks", Columns: []drivers.Column{{Name: "tenant_id"}, {Name: "project_id", Nullable: true}}},
+	}
+	if err := addCompositeFKeys(tables, fkeys); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	added := tables[1].FKeys
+	if len(added) != 2 || added[0].Name != added[1].Name {
+		t.Fatalf("expected 2 columns in the same constraint, got %v", added)
+	}
+	if added[1].Column != "project_id" || added[1].ForeignColumn != "id" || !added[1].Nullable {
+		t.Errorf("unexpected second column: %v", added[1])
+	}
+
+	for _, entry := range []map[string]interface{}{
+		{"name": "a", "table": "tasks", "foreign_table": "projects", "columns": []interface{}{"tenant_id"}, "foreign_columns": []interface{}{}},
+		{"table": "tasks", "foreign_table": "projects", "columns": []interface{}{"id"}, "foreign_columns": []interface{}{"id"}},
+		{"name": "a", "table": "tasks", "foreign_table": "projects", "columns": "id", "foreign_columns": []interface{}{"id"}},
+	} {
+		if _, err := parseCompositeFKeys([]interface{}{entry}); err == nil {
+			t.Errorf("expected an error for %v", entry)
+		}
+	}
+
+	unknown := []compositeFKey{{name: "a", table: "tasks", columns: []string{"missing"}, foreignTable: "projects", foreignColumns: []string{"id"}}}
+	if err := addCompositeFKeys(tables, unknown); err == nil || !strings.Contains(err.Error(), "tasks.missing") {
+		t.Errorf("expected an error for an unknown column, got %v", err)
+	}
+}
+
+func TestCompositeAliases(t *testing.T) {
+	aliases := boilingcore.Aliases{Tables: map[string]boilingcore.TableAlias{
+		"tasks": {Relationships: map[string]boilingcore.RelationshipAlias{
+			"tasks_project_id_fkey": {Local: "Tasks", Foreign: "Project"},
+			"tasks_owner_fkey":      {Local: "OwnedTasks", Foreign: "Owner"},
+		}},
+	}}
+
+	addCompositeAliases(&aliases, []compositeFKey{
+		{name: "tasks_owner_fkey", table: "tasks", foreignTable: "users"},
+		{name: "tasks_project_fkey", table: "tasks", foreignTable: "projects"},
+		{name: "notes_project_fkey", table: "notes", foreignTable: "projects"},
+	})
+
+	tests := []struct {
+		table, fkey, local, foreign string
+	}{
+		{"tasks", "tasks_owner_fkey", "OwnedTasks", "Owner"},
+		{"tasks", "tasks_project_fkey", "Tasks", "TasksProjectFkey"},
+		{"notes", "notes_project_fkey", "Notes", "Project"},
+	}
+
+	for _, tt := range tests {
+		r := aliases.Table(tt.table).Relationship(tt.fkey)
+		if r.Local != tt.local || r.Foreign != tt.foreign {
+			t.Errorf("%s: expected %s and %s, got %v", tt.fkey, tt.local, tt.foreign, r)
+		}
+	}
+}
+
+func TestMarkAssociation(t *testing.T) {
+	contributors := func(pkey ...string) drivers.Table {
+		return drivers.Table{
+			Name: "book_contributors",
+			PKey: &drivers.PrimaryKey{Columns: pkey},
+			FKeys: []drivers.ForeignKey{
+				fkey("book_contributors", "book_id", "books", false),
+				fkey("book_contributors", "author_id", "authors", false),
+				fkey("book_contributors", "editor_id", "authors", true),
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		table    drivers.Table
+		expected []bool
+	}{
+		{
+			name:     "primary key made of foreign keys",
+			table:    contributors("book_id", "author_id"),
+			expected: []bool{true, true, false},
+		},
+		{
+			name:     "primary key with another column",
+			table:    contributors("book_id", "role"),
+			expected: []bool{false, false, false},
+		},
+		{
+			name:     "single column primary key",
+			table:    contributors("book_id"),
+			expected: []bool{false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fkeys := groupFKeys(tt.table.FKeys)
+			markAssociation(tt.table, fkeys)
+
+			for i, fkey := range fkeys {
+				if fkey.Association != tt.expected[i] {
+					t.Errorf("expected %s to be marked %v", fkey.Column, tt.expected[i])
+				}
+			}
+		})
+	}
+}
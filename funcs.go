@@ -0,0 +1,357 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+// templateFunctions are added to the functions sqlboiler makes
+// available to the seed templates
+var templateFunctions = template.FuncMap{
+	"parentFKeys":       parentFKeys,
+	"selfFKeys":         selfFKeys,
+	"deferredFKeys":     deferredFKeys,
+	"perRels":           perRels,
+	"seedOrder":         seedOrder,
+	"columnGenerator":   columnGenerator,
+	"wordList":          wordList,
+	"uniqueColumns":     uniqueColumns,
+	"needsUniqueSuffix": needsUniqueSuffix,
+	"enumTypeColumns":   enumTypeColumns,
+	"isAssociation":     isAssociation,
+	"isUpsert":          isUpsert,
+	"upsertArgs":        upsertArgs,
+	"fixtureFormat":     fixtureFormat,
+	"fixtureRefs":       fixtureRefs,
+	"isReferenced":      isReferenced,
+}
+
+// parentFKeys returns the foreign keys of the table that point to other tables
+// and are set on insert, grouped by constraint. The seeder has to wait for
+// these tables before seeding this one.
+func parentFKeys(tables []drivers.Table, table drivers.Table) ([]foreignKey, error) {
+	deferred, err := cycleBreakers(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	var fkeys []drivers.ForeignKey
+	for _, fkey := range table.FKeys {
+		if fkey.ForeignTable != table.Name && !deferred[fkeyID(fkey)] {
+			fkeys = append(fkeys, fkey)
+		}
+	}
+
+	groups := groupFKeys(fkeys)
+	markAssociation(table, groups)
+
+	return groups, nil
+}
+
+// isAssociation reports whether the table is an association table,
+// whose rows must each link a different combination of parents
+func isAssociation(tables []drivers.Table, table drivers.Table) (bool, error) {
+	fkeys, err := parentFKeys(tables, table)
+	if err != nil {
+		return false, err
+	}
+
+	for _, fkey := range fkeys {
+		if fkey.Association {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// selfFKeys returns the foreign keys of the table that point to the table itself.
+// These are seeded in waves, so the roots of the tree must be able to have
+// a NULL parent.
+func selfFKeys(table drivers.Table) ([]drivers.ForeignKey, error) {
+	var fkeys []drivers.ForeignKey
+	for _, fkey := range table.FKeys {
+		if fkey.ForeignTable != table.Name {
+			continue
+		}
+
+		if !fkey.Nullable {
+			return nil, fmt.Errorf(
+				"table %s has a NOT NULL self-referencing foreign key (%s), its rows cannot be seeded",
+				table.Name, fkey.Column,
+			)
+		}
+
+		fkeys = append(fkeys, fkey)
+	}
+
+	return fkeys, nil
+}
+
+// deferredFKeys returns the foreign keys of the table that are left NULL on insert
+// to break a cycle, grouped by constraint. They are backfilled once every
+// table has been seeded.
+func deferredFKeys(tables []drivers.Table, table drivers.Table) ([]foreignKey, error) {
+	deferred, err := cycleBreakers(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	var fkeys []drivers.ForeignKey
+	for _, fkey := range table.FKeys {
+		if deferred[fkeyID(fkey)] {
+			fkeys = append(fkeys, fkey)
+		}
+	}
+
+	return groupFKeys(fkeys), nil
+}
+
+// perRels returns the one-to-many relationships of the table whose
+// number of children is controlled with an XPerY field
+func perRels(tables []drivers.Table, table drivers.Table) ([]drivers.ToManyRelationship, error) {
+	deferred, err := cycleBreakers(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	var rels []drivers.ToManyRelationship
+	for _, rel := range table.ToManyRelationships {
+		if rel.ToJoinTable || rel.Table == rel.ForeignTable {
+			continue
+		}
+
+		child := drivers.GetTable(tables, rel.ForeignTable)
+		isDeferred := false
+		for _, fkey := range child.FKeys {
+			if fkey.Column == rel.ForeignColumn && fkey.ForeignTable == rel.Table && deferred[fkeyID(fkey)] {
+				isDeferred = true
+			}
+		}
+
+		if !isDeferred {
+			rels = append(rels, rel)
+		}
+	}
+
+	return rels, nil
+}
+
+// isReferenced reports whether other tables have foreign keys to the table.
+// It goes through the foreign keys instead of the relationships sqlboiler finds,
+// since those do not include the composite keys declared in the config.
+func isReferenced(tables []drivers.Table, table drivers.Table) bool {
+	for _, t := range tables {
+		if t.Name == table.Name || t.IsView {
+			continue
+		}
+
+		for _, fkey := range t.FKeys {
+			if fkey.ForeignTable == table.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// uniqueColumns returns the columns of the table with a single-column UNIQUE constraint
+// that get their values from RandomX, so duplicates have to be avoided by the seeder.
+// Columns with defaults and foreign keys are not set by RandomX and are left out.
+func uniqueColumns(table drivers.Table) []drivers.Column {
+	var columns []drivers.Column
+	for _, column := range table.Columns {
+		isPKey := table.PKey != nil && len(table.PKey.Columns) == 1 && table.PKey.Columns[0] == column.Name
+		if !column.Unique && !isPKey {
+			continue
+		}
+
+		if column.Default != "" || column.AutoGenerated {
+			continue
+		}
+
+		isFKey := false
+		for _, fkey := range table.FKeys {
+			if fkey.Column == column.Name {
+				isFKey = true
+			}
+		}
+
+		if !isFKey {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// seedOrder sorts the tables that can be seeded so that every table
+// comes after the tables it has to wait for.
+func seedOrder(tables []drivers.Table) ([]drivers.Table, error) {
+	pending := map[string]bool{}
+	for _, table := range tables {
+		if !table.IsView {
+			pending[table.Name] = true
+		}
+	}
+
+	var ordered []drivers.Table
+	for len(pending) > 0 {
+		added := false
+
+		for _, table := range tables {
+			if !pending[table.Name] {
+				continue
+			}
+
+			parents, err := parentFKeys(tables, table)
+			if err != nil {
+				return nil, err
+			}
+
+			ready := true
+			for _, fkey := range parents {
+				if pending[fkey.ForeignTable] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				delete(pending, table.Name)
+				ordered = append(ordered, table)
+				added = true
+			}
+		}
+
+		if !added {
+			return nil, errors.New("could not find an order to seed the tables in")
+		}
+	}
+
+	return ordered, nil
+}
+
+// fkeyID identifies a foreign key constraint.
+// Composite keys share the same ID.
+func fkeyID(fkey drivers.ForeignKey) string {
+	return fkey.Table + "." + fkey.Name
+}
+
+// cycleBreakers finds the cycles formed by foreign keys between the tables
+// and picks a nullable key in each of them to be set after insert.
+// It returns an error if a cycle has no key that can be deferred.
+func cycleBreakers(tables []drivers.Table) (map[string]bool, error) {
+	deferred := map[string]bool{}
+
+	// a composite key can be left NULL if any of its columns can
+	nullable := map[string]bool{}
+	for _, table := range tables {
+		for _, fkey := range table.FKeys {
+			nullable[fkeyID(fkey)] = nullable[fkeyID(fkey)] || fkey.Nullable
+		}
+	}
+
+	for {
+		cycle := findCycle(tables, deferred)
+		if cycle == nil {
+			return deferred, nil
+		}
+
+		broken := false
+		for _, fkey := range cycle {
+			// the row has to be updated to backfill the key
+			// which is only possible with a primary key
+			if nullable[fkeyID(fkey)] && drivers.GetTable(tables, fkey.Table).PKey != nil {
+				deferred[fkeyID(fkey)] = true
+				broken = true
+				break
+			}
+		}
+
+		if !broken {
+			path := make([]string, len(cycle))
+			for i, fkey := range cycle {
+				path[i] = fmt.Sprintf("%s.%s -> %s", fkey.Table, fkey.Column, fkey.ForeignTable)
+			}
+
+			return nil, fmt.Errorf(
+				"foreign keys form a cycle that cannot be broken because every key in it is NOT NULL: %s",
+				strings.Join(path, ", "),
+			)
+		}
+	}
+}
+
+// findCycle returns the foreign keys that make up the first cycle found
+// between the tables, ignoring self references and deferred keys.
+// It returns nil if there are no cycles.
+func findCycle(tables []drivers.Table, deferred map[string]bool) []drivers.ForeignKey {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	seedable := map[string]drivers.Table{}
+	for _, table := range tables {
+		if !table.IsView {
+			seedable[table.Name] = table
+		}
+	}
+
+	state := map[string]int{}
+	var path []drivers.ForeignKey
+
+	var visit func(name string) []drivers.ForeignKey
+	visit = func(name string) []drivers.ForeignKey {
+		state[name] = visiting
+
+		for _, fkey := range seedable[name].FKeys {
+			if fkey.ForeignTable == name || deferred[fkeyID(fkey)] {
+				continue
+			}
+			if _, ok := seedable[fkey.ForeignTable]; !ok {
+				continue
+			}
+
+			path = append(path, fkey)
+
+			switch state[fkey.ForeignTable] {
+			case visiting:
+				// the cycle starts where the foreign table was first entered
+				for i, f := range path {
+					if f.Table == fkey.ForeignTable {
+						return path[i:]
+					}
+				}
+			case unvisited:
+				if cycle := visit(fkey.ForeignTable); cycle != nil {
+					return cycle
+				}
+			}
+
+			path = path[:len(path)-1]
+		}
+
+		state[name] = visited
+		return nil
+	}
+
+	for _, table := range tables {
+		if _, ok := seedable[table.Name]; !ok || state[table.Name] != unvisited {
+			continue
+		}
+
+		if cycle := visit(table.Name); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+func fkey(table, column, foreignTable string, nullable bool) drivers.ForeignKey {
+	return drivers.ForeignKey{
+		Table:         table,
+		Name:          table + "_" + column + "_fkey",
+		Column:        column,
+		Nullable:      nullable,
+		ForeignTable:  foreignTable,
+		ForeignColumn: "id",
+	}
+}
+
+func table(name string, fkeys ...drivers.ForeignKey) drivers.Table {
+	return drivers.Table{
+		Name:  name,
+		PKey:  &drivers.PrimaryKey{Columns: []string{"id"}},
+		FKeys: fkeys,
+	}
+}
+
+func TestCycleBreakers(t *testing.T) {
+	tests := []struct {
+		name     string
+		tables   []drivers.Table
+		deferred []string
+		err      string
+	}{
+		{
+			name: "no cycles",
+			tables: []drivers.Table{
+				table("pilots"),
+				table("jets", fkey("jets", "pilot_id", "pilots", false)),
+			},
+		},
+		{
+			name: "self reference is not a cycle",
+			tables: []drivers.Table{
+				table("employees", fkey("employees", "manager_id", "employees", true)),
+			},
+		},
+		{
+			name: "nullable side is deferred",
+			tables: []drivers.Table{
+				table("teams", fkey("teams", "captain_id", "players", true)),
+				table("players", fkey("players", "team_id", "teams", false)),
+			},
+			deferred: []string{"teams.teams_captain_id_fkey"},
+		},
+		{
+			name: "longer cycle",
+			tables: []drivers.Table{
+				table("a", fkey("a", "b_id", "b", false)),
+				table("b", fkey("b", "c_id", "c", false)),
+				table("c", fkey("c", "a_id", "a", true)),
+			},
+			deferred: []string{"c.c_a_id_fkey"},
+		},
+		{
+			name: "views are ignored",
+			tables: []drivers.Table{
+				table("a", fkey("a", "b_id", "b", false)),
+				{Name: "b", IsView: true, FKeys: []drivers.ForeignKey{fkey("b", "a_id", "a", false)}},
+			},
+		},
+		{
+			name: "every key is NOT NULL",
+			tables: []drivers.Table{
+				table("a", fkey("a", "b_id", "b", false)),
+				table("b", fkey("b", "a_id", "a", false)),
+			},
+			err: "a.b_id -> b, b.a_id -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deferred, err := cycleBreakers(tt.tables)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(deferred) != len(tt.deferred) {
+				t.Fatalf("expected %d deferred keys, got %v", len(tt.deferred), deferred)
+			}
+			for _, id := range tt.deferred {
+				if !deferred[id] {
+					t.Errorf("expected %s to be deferred, got %v", id, deferred)
+				}
+			}
+		})
+	}
+}
+
+func TestSelfFKeys(t *testing.T) {
+	fkeys, err := selfFKeys(table("employees",
+		fkey("employees", "manager_id", "employees", true),
+		fkey("employees", "team_id", "teams", false),
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fkeys) != 1 || fkeys[0].Column != "manager_id" {
+		t.Errorf("expected only manager_id, got %v", fkeys)
+	}
+
+	_, err = selfFKeys(table("employees", fkey("employees", "manager_id", "employees", false)))
+	if err == nil {
+		t.Error("expected an error for a NOT NULL self reference")
+	}
+}
+
+func TestSeedOrder(t *testing.T) {
+	tables := []drivers.Table{
+		table("jets", fkey("jets", "pilot_id", "pilots", false)),
+		table("pilot_languages",
+			fkey("pilot_languages", "pilot_id", "pilots", false),
+			fkey("pilot_languages", "language_id", "languages", false),
+		),
+		table("pilots", fkey("pilots", "mentor_id", "pilots", true)),
+		{Name: "jet_summary", IsView: true},
+		table("languages"),
+		table("teams", fkey("teams", "captain_id", "players", true)),
+		table("players", fkey("players", "team_id", "teams", false)),
+	}
+
+	ordered, err := seedOrder(tables)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := make([]string, len(ordered))
+	for i, table := range ordered {
+		names[i] = table.Name
+	}
+
+	expected := "pilots,languages,teams,players,jets,pilot_languages"
+	if got := strings.Join(names, ","); got != expected {
+		t.Errorf("expected order %s, got %s", expected, got)
+	}
+}
+
+func TestIsReferenced(t *testing.T) {
+	// tasks references projects through a composite key declared in the config
+	tasks := table("tasks",
+		drivers.ForeignKey{Table: "tasks", Name: "tasks_project_fkey", Column: "tenant_id", ForeignTable: "projects", ForeignColumn: "tenant_id"},
+		drivers.ForeignKey{Table: "tasks", Name: "tasks_project_fkey", Column: "project_id", ForeignTable: "projects", ForeignColumn: "id"},
+	)
+	tables := []drivers.Table{
+		table("tenants"),
+		table("projects", fkey("projects", "tenant_id", "tenants", false)),
+		tasks,
+		table("categories", fkey("categories", "parent_id", "categories", true)),
+	}
+
+	expected := []bool{true, true, false, false}
+	for i, table := range tables {
+		if got := isReferenced(tables, table); got != expected[i] {
+			t.Errorf("%s: expected %v, got %v", table.Name, expected[i], got)
+		}
+	}
+}
+
+func TestUniqueColumns(t *testing.T) {
+	table := drivers.Table{
+		Name: "users",
+		PKey: &drivers.PrimaryKey{Columns: []string{"id"}},
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int64", Default: "auto_increment"},
+			{Name: "email", Type: "string", Unique: true},
+			{Name: "nickname", Type: "null.String", Unique: true},
+			{Name: "team_id", Type: "int64", Unique: true},
+			{Name: "token", Type: "string", Unique: true, AutoGenerated: true},
+			{Name: "age", Type: "int"},
+		},
+		FKeys: []drivers.ForeignKey{{Column: "team_id", ForeignTable: "teams", ForeignColumn: "id"}},
+	}
+
+	var names []string
+	for _, column := range uniqueColumns(table) {
+		names = append(names, column.Name)
+	}
+
+	if strings.Join(names, ",") != "email,nickname" {
+		t.Errorf("expected email,nickname, got %v", names)
+	}
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+	"github.com/aarondl/strmangle"
+)
+
+// generators maps a column pattern (table.column, with * wildcards)
+// or a DB type to the generator used for matching columns.
+// It is read from the [boilingseed.generators] config section.
+var generators map[string]generator
+
+// generator is a named source of realistic values for a column
+type generator struct {
+	name string
+	// from and to bound the values of the daterange generator
+	from, to time.Time
+	// min and max bound the values of the range generator
+	min, max float64
+	// values are the SQL literals the in and enum generators pick from
+	values []string
+}
+
+// generatorFuncs maps the names of the generators that produce strings
+// to the function in the generated code that produces their values
+var generatorFuncs = map[string]string{
+	"email":      "randomEmail",
+	"name":       "randomName",
+	"first_name": "randomFirstName",
+	"last_name":  "randomLastName",
+	"address":    "randomAddress",
+	"city":       "randomCity",
+	"company":    "randomCompany",
+	"url":        "randomURL",
+	"uuid":       "randomUUID",
+	"phone":      "randomPhone",
+	"lorem":      "randomLorem",
+}
+
+// conventions maps common column names to the generator used for them
+// when no generator is configured for the column
+var conventions = []struct {
+	pattern   string
+	generator string
+}{
+	{"email", "email"},
+	{"*_email", "email"},
+	{"first_name", "first_name"},
+	{"firstname", "first_name"},
+	{"last_name", "last_name"},
+	{"lastname", "last_name"},
+	{"surname", "last_name"},
+	{"full_name", "name"},
+	{"address", "address"},
+	{"*_address", "address"},
+	{"street", "address"},
+	{"city", "city"},
+	{"company", "company"},
+	{"company_name", "company"},
+	{"url", "url"},
+	{"*_url", "url"},
+	{"website", "url"},
+	{"phone", "phone"},
+	{"*_phone", "phone"},
+	{"phone_number", "phone"},
+	{"mobile", "phone"},
+	{"description", "lorem"},
+	{"bio", "lorem"},
+	{"summary", "lorem"},
+	{"body", "lorem"},
+	{"content", "lorem"},
+}
+
+// uniqueGenerators produce a different value every time,
+// so they can be used for UNIQUE columns by convention
+var uniqueGenerators = map[string]bool{
+	"email": true,
+	"url":   true,
+	"uuid":  true,
+}
+
+var (
+	dateRangeRgx = regexp.MustCompile(`^daterange\(\s*(\d{4}-\d{2}-\d{2})\s*,\s*(\d{4}-\d{2}-\d{2})\s*\)$`)
+	rangeRgx     = regexp.MustCompile(`^range\(\s*([-+.\d]+)\s*,\s*([-+.\d]+)\s*\)$`)
+	inRgx        = regexp.MustCompile(`^in\((.+)\)$`)
+	inValueRgx   = regexp.MustCompile(`^\s*('(?:[^']|'')*'|[-+.\d]+)\s*(?:,|$)`)
+)
+
+// baseTypes maps the Go types the range and in generators can be used for
+// to the type of the value they hold
+var baseTypes = map[string]string{
+	"string":       "string",
+	"null.String":  "string",
+	"int":          "int",
+	"int8":         "int8",
+	"int16":        "int16",
+	"int32":        "int32",
+	"int64":        "int64",
+	"uint":         "uint",
+	"uint8":        "uint8",
+	"uint16":       "uint16",
+	"uint32":       "uint32",
+	"uint64":       "uint64",
+	"float32":      "float32",
+	"float64":      "float64",
+	"null.Int":     "int",
+	"null.Int8":    "int8",
+	"null.Int16":   "int16",
+	"null.Int32":   "int32",
+	"null.Int64":   "int64",
+	"null.Uint":    "uint",
+	"null.Uint8":   "uint8",
+	"null.Uint16":  "uint16",
+	"null.Uint32":  "uint32",
+	"null.Uint64":  "uint64",
+	"null.Float32": "float32",
+	"null.Float64": "float64",
+}
+
+// parseGenerators reads the generators config section.
+// Keys with dots are nested by the config reader, so they are joined back into patterns.
+func parseGenerators(section interface{}) (map[string]generator, error) {
+	flat := map[string]string{}
+	if err := flattenGenerators("", section, flat); err != nil {
+		return nil, err
+	}
+
+	gens := make(map[string]generator, len(flat))
+	for key, spec := range flat {
+		gen, err := parseGenerator(spec)
+		if err != nil {
+			return nil, fmt.Errorf("generator for %s: %w", key, err)
+		}
+		gens[key] = gen
+	}
+
+	return gens, nil
+}
+
+func flattenGenerators(prefix string, section interface{}, flat map[string]string) error {
+	switch section := section.(type) {
+	case nil:
+		return nil
+	case string:
+		flat[prefix] = section
+		return nil
+	case map[string]interface{}:
+		for key, value := range section {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := flattenGenerators(key, value, flat); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("generator for %s must be a string, got %T", prefix, section)
+	}
+}
+
+func parseGenerator(spec string) (generator, error) {
+	spec = strings.TrimSpace(spec)
+	if _, ok := generatorFuncs[spec]; ok || spec == "random" {
+		return generator{name: spec}, nil
+	}
+
+	if matches := rangeRgx.FindStringSubmatch(spec); matches != nil {
+		return parseRange(matches[1], matches[2])
+	}
+
+	if matches := inRgx.FindStringSubmatch(spec); matches != nil {
+		return parseIn(matches[1])
+	}
+
+	matches := dateRangeRgx.FindStringSubmatch(spec)
+	if matches == nil {
+		return generator{}, fmt.Errorf("unknown generator %q", spec)
+	}
+
+	from, err := time.Parse("2006-01-02", matches[1])
+	if err != nil {
+		return generator{}, fmt.Errorf("invalid start of date range: %w", err)
+	}
+	to, err := time.Parse("2006-01-02", matches[2])
+	if err != nil {
+		return generator{}, fmt.Errorf("invalid end of date range: %w", err)
+	}
+	if to.Before(from) {
+		return generator{}, fmt.Errorf("date range ends before it starts: %s", spec)
+	}
+
+	return generator{name: "daterange", from: from, to: to}, nil
+}
+
+// parseRange parses range(min, max), the values allowed by CHECK (col BETWEEN min AND max)
+func parseRange(from, to string) (generator, error) {
+	min, err := strconv.ParseFloat(from, 64)
+	if err != nil {
+		return generator{}, fmt.Errorf("invalid start of range: %w", err)
+	}
+	max, err := strconv.ParseFloat(to, 64)
+	if err != nil {
+		return generator{}, fmt.Errorf("invalid end of range: %w", err)
+	}
+	if max < min {
+		return generator{}, fmt.Errorf("range ends before it starts: range(%s, %s)", from, to)
+	}
+
+	return generator{name: "range", min: min, max: max}, nil
+}
+
+// parseIn parses in('a', 'b') or in(1, 2), the values allowed by CHECK (col IN (...)).
+// The values are either all quoted strings or all numbers.
+func parseIn(list string) (generator, error) {
+	var values []string
+	for rest := list; strings.TrimSpace(rest) != ""; {
+		matches := inValueRgx.FindStringSubmatch(rest)
+		if matches == nil {
+			return generator{}, fmt.Errorf("invalid value in in(%s) at %q", list, strings.TrimSpace(rest))
+		}
+
+		values = append(values, matches[1])
+		rest = rest[len(matches[0]):]
+	}
+
+	for _, value := range values[1:] {
+		if isQuoted(value) != isQuoted(values[0]) {
+			return generator{}, fmt.Errorf("the values of in(%s) must all be strings or all be numbers", list)
+		}
+	}
+
+	return generator{name: "in", values: values}, nil
+}
+
+func isQuoted(value string) bool {
+	return strings.HasPrefix(value, "'")
+}
+
+// findGenerator returns the generator for the column.
+// An exact table.column match wins over a pattern, a pattern over a DB type,
+// and a DB type over the conventions. The "random" generator turns them off.
+// Enum columns that are not configured pick one of the values of the enum.
+func findGenerator(table drivers.Table, column drivers.Column) (generator, bool) {
+	gen, ok := configuredGenerator(table, column)
+	if ok && gen.name != "random" {
+		return gen, true
+	}
+
+	// enum columns always pick one of their values, or the insert fails
+	if drivers.IsEnumDBType(column.DBType) {
+		var values []string
+		for _, value := range strmangle.ParseEnumVals(column.DBType) {
+			values = append(values, "'"+value+"'")
+		}
+		return generator{name: "enum", values: values}, true
+	}
+
+	if ok || !isStringType(column.Type) {
+		return generator{}, false
+	}
+
+	for _, convention := range conventions {
+		if ok, _ := path.Match(convention.pattern, strings.ToLower(column.Name)); !ok {
+			continue
+		}
+
+		if column.Unique && !uniqueGenerators[convention.generator] {
+			return generator{}, false
+		}
+
+		return generator{name: convention.generator}, true
+	}
+
+	return generator{}, false
+}
+
+func isStringType(typ string) bool {
+	return typ == "string" || typ == "null.String"
+}
+
+// configuredGenerator returns the generator set for the column in the config
+func configuredGenerator(table drivers.Table, column drivers.Column) (generator, bool) {
+	name := table.Name + "." + column.Name
+	if gen, ok := generators[name]; ok {
+		return gen, true
+	}
+
+	patterns := make([]string, 0, len(generators))
+	for pattern := range generators {
+		if strings.Contains(pattern, ".") {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return generators[pattern], true
+		}
+	}
+
+	gen, ok := generators[strings.ToLower(column.DBType)]
+	return gen, ok
+}
+
+// columnGenerator returns the Go expression that generates a value for the column
+// in defaultRandomX, or an empty string if the column has no generator.
+func columnGenerator(table drivers.Table, column drivers.Column) (string, error) {
+	gen, ok := findGenerator(table, column)
+	if !ok {
+		return "", nil
+	}
+
+	switch gen.name {
+	case "range", "in", "enum":
+		return valuesGenerator(table, column, gen)
+	}
+
+	if gen.name == "daterange" {
+		expr := fmt.Sprintf("randomDate(seed.NextInt(), %d, %d)", gen.from.Unix(), gen.to.Unix())
+		switch column.Type {
+		case "time.Time":
+			return expr, nil
+		case "null.Time":
+			return fmt.Sprintf("null.TimeFrom(%s)", expr), nil
+		case "string":
+			return fmt.Sprintf("%s.Format(\"2006-01-02\")", expr), nil
+		case "null.String":
+			return fmt.Sprintf("null.StringFrom(%s.Format(\"2006-01-02\"))", expr), nil
+		}
+	} else {
+		expr := fmt.Sprintf("%s(seed.NextInt())", generatorFuncs[gen.name])
+		switch column.Type {
+		case "string":
+			return expr, nil
+		case "null.String":
+			return fmt.Sprintf("null.StringFrom(%s)", expr), nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"the %s generator cannot be used for %s.%s of type %s",
+		gen.name, table.Name, column.Name, column.Type,
+	)
+}
+
+// valuesGenerator returns the expression for the generators that are limited to
+// a range or a list of values
+func valuesGenerator(table drivers.Table, column drivers.Column, gen generator) (string, error) {
+	base, ok := baseTypes[column.Type]
+	if !ok && gen.name == "enum" {
+		// the enum types that sqlboiler generates with --add-enum-types
+		return enumTypeGenerator(table, column, gen)
+	}
+
+	var expr string
+	switch {
+	case !ok:
+	case gen.name == "range" && strings.HasPrefix(base, "float"):
+		expr = fmt.Sprintf("randomFloat(seed.NextInt(), %v, %v)", gen.min, gen.max)
+		if base != "float64" {
+			expr = fmt.Sprintf("%s(%s)", base, expr)
+		}
+	case gen.name == "range" && base != "string":
+		if gen.min != float64(int64(gen.min)) || gen.max != float64(int64(gen.max)) {
+			return "", fmt.Errorf("the range of %s.%s of type %s must be whole numbers", table.Name, column.Name, column.Type)
+		}
+		expr = fmt.Sprintf("randomInt(seed.NextInt(), %d, %d)", int64(gen.min), int64(gen.max))
+		if base != "int64" {
+			expr = fmt.Sprintf("%s(%s)", base, expr)
+		}
+	case gen.name != "range" && isQuoted(gen.values[0]) == (base == "string"):
+		expr = fmt.Sprintf("[]%s{%s}[mix(seed.NextInt())%%%d]", base, goLiterals(gen.values), len(gen.values))
+	}
+
+	if expr == "" {
+		return "", fmt.Errorf(
+			"the %s generator cannot be used for %s.%s of type %s",
+			gen.name, table.Name, column.Name, column.Type,
+		)
+	}
+
+	if strings.HasPrefix(column.Type, "null.") {
+		return fmt.Sprintf("%sFrom(%s)", column.Type, expr), nil
+	}
+
+	return expr, nil
+}
+
+// enumTypeGenerator returns the expression for a column with a generated enum type.
+// Nullable columns use the Null type, built from the enum type.
+func enumTypeGenerator(table drivers.Table, column drivers.Column, gen generator) (string, error) {
+	expr := fmt.Sprintf("[]string{%s}[mix(seed.NextInt())%%%d]", goLiterals(gen.values), len(gen.values))
+	if !column.Nullable {
+		return fmt.Sprintf("models.%s(%s)", column.Type, expr), nil
+	}
+
+	enumType := strmangle.TitleCase(table.Name) + strmangle.TitleCase(column.Name)
+	if name := strmangle.ParseEnumName(column.DBType); name != "" {
+		enumType = strmangle.TitleCase(name)
+	}
+
+	return fmt.Sprintf("models.%sFrom(models.%s(%s))", column.Type, enumType, expr), nil
+}
+
+// enumTypeColumns returns the columns with a generated enum type.
+// randomize cannot fill the Null enum types, and the generators set them anyway.
+func enumTypeColumns(table drivers.Table) []drivers.Column {
+	var columns []drivers.Column
+	for _, column := range table.Columns {
+		if _, ok := baseTypes[column.Type]; !ok && drivers.IsEnumDBType(column.DBType) {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// goLiterals turns SQL literals into Go literals
+func goLiterals(values []string) string {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		if isQuoted(value) {
+			value = strconv.Quote(strings.ReplaceAll(value[1:len(value)-1], "''", "'"))
+		}
+		literals[i] = value
+	}
+
+	return strings.Join(literals, ", ")
+}
+
+// needsUniqueSuffix reports whether defaultRandomX has to append a number
+// from the seed to the value of a UNIQUE string column, because the generator
+// of the column does not produce unique values.
+func needsUniqueSuffix(table drivers.Table, column drivers.Column) bool {
+	if !isStringType(column.Type) {
+		return false
+	}
+
+	// a suffix would take the values of the other generators out of their range
+	gen, ok := findGenerator(table, column)
+	_, isFaker := generatorFuncs[gen.name]
+	return !ok || (isFaker && !uniqueGenerators[gen.name])
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+func TestParseGenerators(t *testing.T) {
+	// keys with dots are nested by viper
+	gens, err := parseGenerators(map[string]interface{}{
+		"*": map[string]interface{}{"email": "email"},
+		"users": map[string]interface{}{
+			"first_name": "name",
+			"joined_on":  "daterange(2020-01-01, 2020-12-31)",
+			"age":        "range(0, 120)",
+			"role":       "in('admin', 'user''s')",
+		},
+		"uuid": "uuid",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"*.email":          "email",
+		"users.first_name": "name",
+		"users.joined_on":  "daterange",
+		"users.age":        "range",
+		"users.role":       "in",
+		"uuid":             "uuid",
+	}
+	if len(gens) != len(expected) {
+		t.Fatalf("expected %d generators, got %v", len(expected), gens)
+	}
+	for key, name := range expected {
+		if gens[key].name != name {
+			t.Errorf("expected %s to use %s, got %q", key, name, gens[key].name)
+		}
+	}
+
+	if gens["users.joined_on"].from.Format("2006-01-02") != "2020-01-01" {
+		t.Errorf("unexpected start of date range: %v", gens["users.joined_on"].from)
+	}
+
+	if gens["users.age"].max != 120 {
+		t.Errorf("unexpected end of range: %v", gens["users.age"].max)
+	}
+	if strings.Join(gens["users.role"].values, ",") != "'admin','user''s'" {
+		t.Errorf("unexpected values: %v", gens["users.role"].values)
+	}
+
+	for _, spec := range []string{
+		"unknown", "daterange(2020-12-31, 2020-01-01)", "daterange(2020-01-01)",
+		"range(10, 1)", "range(a, b)", "in('a', 1)", "in(a b)",
+	} {
+		if _, err := parseGenerators(map[string]interface{}{"users": map[string]interface{}{"email": spec}}); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestColumnGenerator(t *testing.T) {
+	generators = map[string]generator{
+		"*.email":     {name: "email"},
+		"users.email": {name: "name"},
+		"uuid":        {name: "uuid"},
+		"posts.body":  {name: "random"},
+		"users.years": {name: "range", min: 0, max: 120},
+		"users.score": {name: "range", min: 0, max: 1},
+		"users.role":  {name: "in", values: []string{"'admin'", "'user''s'"}},
+		"users.level": {name: "in", values: []string{"1", "2"}},
+		"posts.state": {name: "random"},
+	}
+	defer func() { generators = nil }()
+
+	users := drivers.Table{Name: "users"}
+	tests := []struct {
+		name   string
+		table  drivers.Table
+		column drivers.Column
+		expr   string
+		err    string
+	}{
+		{
+			name:   "exact column wins over pattern",
+			table:  users,
+			column: drivers.Column{Name: "email", Type: "string", DBType: "text"},
+			expr:   "randomName(seed.NextInt())",
+		},
+		{
+			name:   "pattern",
+			table:  drivers.Table{Name: "authors"},
+			column: drivers.Column{Name: "email", Type: "null.String", DBType: "text"},
+			expr:   "null.StringFrom(randomEmail(seed.NextInt()))",
+		},
+		{
+			name:   "db type",
+			table:  users,
+			column: drivers.Column{Name: "token", Type: "string", DBType: "UUID"},
+			expr:   "randomUUID(seed.NextInt())",
+		},
+		{
+			name:   "convention",
+			table:  users,
+			column: drivers.Column{Name: "first_name", Type: "string", DBType: "text"},
+			expr:   "randomFirstName(seed.NextInt())",
+		},
+		{
+			name:   "convention for a unique column",
+			table:  users,
+			column: drivers.Column{Name: "company", Type: "string", DBType: "text", Unique: true},
+		},
+		{
+			name:   "convention for another type",
+			table:  users,
+			column: drivers.Column{Name: "phone", Type: "int64", DBType: "integer"},
+		},
+		{
+			name:   "random turns off the convention",
+			table:  drivers.Table{Name: "posts"},
+			column: drivers.Column{Name: "body", Type: "string", DBType: "text"},
+		},
+		{
+			name:   "range",
+			table:  users,
+			column: drivers.Column{Name: "years", Type: "null.Int", DBType: "integer"},
+			expr:   "null.IntFrom(int(randomInt(seed.NextInt(), 0, 120)))",
+		},
+		{
+			name:   "float range",
+			table:  users,
+			column: drivers.Column{Name: "score", Type: "float64", DBType: "real"},
+			expr:   "randomFloat(seed.NextInt(), 0, 1)",
+		},
+		{
+			name:   "in",
+			table:  users,
+			column: drivers.Column{Name: "role", Type: "string", DBType: "text"},
+			expr:   `[]string{"admin", "user's"}[mix(seed.NextInt())%2]`,
+		},
+		{
+			name:   "numbers in",
+			table:  users,
+			column: drivers.Column{Name: "level", Type: "int16", DBType: "smallint"},
+			expr:   "[]int16{1, 2}[mix(seed.NextInt())%2]",
+		},
+		{
+			name:   "enum",
+			table:  drivers.Table{Name: "posts"},
+			column: drivers.Column{Name: "state", Type: "null.String", DBType: "enum.post_state('draft','published')"},
+			expr:   `null.StringFrom([]string{"draft", "published"}[mix(seed.NextInt())%2])`,
+		},
+		{
+			name:   "enum type",
+			table:  drivers.Table{Name: "posts"},
+			column: drivers.Column{Name: "state", Type: "PostState", DBType: "enum.post_state('draft','published')"},
+			expr:   `models.PostState([]string{"draft", "published"}[mix(seed.NextInt())%2])`,
+		},
+		{
+			name:   "nullable enum type",
+			table:  drivers.Table{Name: "posts"},
+			column: drivers.Column{Name: "state", Type: "NullPostState", DBType: "enum.post_state('draft','published')", Nullable: true},
+			expr:   `models.NullPostStateFrom(models.PostState([]string{"draft", "published"}[mix(seed.NextInt())%2]))`,
+		},
+		{
+			name:   "nullable mysql enum type",
+			table:  drivers.Table{Name: "posts"},
+			column: drivers.Column{Name: "state", Type: "PostsNullState", DBType: "enum('draft','published')", Nullable: true},
+			expr:   `models.PostsNullStateFrom(models.PostsState([]string{"draft", "published"}[mix(seed.NextInt())%2]))`,
+		},
+		{
+			name:   "range for a string",
+			table:  users,
+			column: drivers.Column{Name: "years", Type: "string", DBType: "text"},
+			err:    "cannot be used for users.years of type string",
+		},
+		{
+			name:   "strings in for a number",
+			table:  users,
+			column: drivers.Column{Name: "role", Type: "int", DBType: "integer"},
+			err:    "cannot be used for users.role of type int",
+		},
+		{
+			name:   "no generator",
+			table:  users,
+			column: drivers.Column{Name: "age", Type: "int", DBType: "integer"},
+		},
+		{
+			name:   "wrong type",
+			table:  drivers.Table{Name: "authors"},
+			column: drivers.Column{Name: "email", Type: "int", DBType: "integer"},
+			err:    "cannot be used for authors.email of type int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := columnGenerator(tt.table, tt.column)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if expr != tt.expr {
+				t.Errorf("expected %q, got %q", tt.expr, expr)
+			}
+		})
+	}
+}
+
+func TestNeedsUniqueSuffix(t *testing.T) {
+	generators = map[string]generator{
+		"users.handle": {name: "name"},
+		"users.role":   {name: "in", values: []string{"'admin'", "'user'"}},
+	}
+	defer func() { generators = nil }()
+
+	users := drivers.Table{Name: "users"}
+	tests := map[string]bool{
+		"handle": true,  // names repeat
+		"email":  false, // emails are unique by convention
+		"role":   false, // a suffix would not be one of the values
+		"code":   true,  // no generator
+	}
+	for name, expected := range tests {
+		column := drivers.Column{Name: name, Type: "string", DBType: "text", Unique: true}
+		if got := needsUniqueSuffix(users, column); got != expected {
+			t.Errorf("expected %v for %s, got %v", expected, name, got)
+		}
+	}
+
+	if needsUniqueSuffix(users, drivers.Column{Name: "code", Type: "int64", Unique: true}) {
+		t.Error("expected no suffix for an int column")
+	}
+}
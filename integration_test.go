@@ -26,7 +26,9 @@ CREATE TABLE categories (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL UNIQUE,
     description TEXT,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    parent_id INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (parent_id) REFERENCES categories(id)
 );
 
 CREATE TABLE books (
@@ -36,7 +38,7 @@ CREATE TABLE books (
     author_id INTEGER NOT NULL,
     category_id INTEGER NOT NULL,
     published_date DATE,
-    pages INTEGER,
+    pages INTEGER CHECK (pages BETWEEN 1 AND 2000),
     price DECIMAL(10,2),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (author_id) REFERENCES authors(id),
@@ -50,6 +52,77 @@ CREATE TABLE book_tags (
     FOREIGN KEY (book_id) REFERENCES books(id)
 );
 
+CREATE TABLE teams (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    captain_id INTEGER,
+    FOREIGN KEY (captain_id) REFERENCES players(id)
+);
+
+CREATE TABLE players (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    team_id INTEGER NOT NULL,
+    position TEXT CHECK (position IN ('goalkeeper', 'defender', 'midfielder', 'forward')),
+    FOREIGN KEY (team_id) REFERENCES teams(id)
+);
+
+CREATE TABLE reviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    body TEXT NOT NULL,
+    reviewer_id INTEGER,
+    FOREIGN KEY (reviewer_id) REFERENCES authors(id)
+);
+
+CREATE TABLE author_categories (
+    author_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    PRIMARY KEY (author_id, category_id),
+    FOREIGN KEY (author_id) REFERENCES authors(id),
+    FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+CREATE TABLE book_contributors (
+    book_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL,
+    category_id INTEGER NOT NULL,
+    role TEXT NOT NULL,
+    joined_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (book_id, author_id, category_id),
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (author_id) REFERENCES authors(id),
+    FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+CREATE TABLE tenants (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE projects (
+    tenant_id INTEGER NOT NULL,
+    id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, id),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+
+CREATE TABLE tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id INTEGER NOT NULL,
+    project_id INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    FOREIGN KEY (tenant_id, project_id) REFERENCES projects(tenant_id, id)
+);
+
+CREATE TABLE milestones (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id INTEGER NOT NULL,
+    project_id INTEGER,
+    title TEXT NOT NULL,
+    FOREIGN KEY (tenant_id, project_id) REFERENCES projects(tenant_id, id)
+);
+
 CREATE VIEW book_summary AS
 SELECT
     b.id,
@@ -80,6 +153,30 @@ JOIN categories c ON b.category_id = c.id;
   port    = 3306
   user    = "test"
   pass    = "test"
+
+[boilingseed]
+  fixture_formats = ["yaml", "toml"]
+
+[boilingseed.generators]
+  "*.email"              = "email"
+  "authors.name"         = "name"
+  "books.published_date" = "daterange(2020-01-01, 2024-12-31)"
+  "books.pages"          = "range(50, 900)"
+  "players.position"     = "in('goalkeeper', 'defender', 'midfielder', 'forward')"
+
+[[boilingseed.foreign_keys]]
+  name            = "tasks_project_fkey"
+  table           = "tasks"
+  columns         = ["tenant_id", "project_id"]
+  foreign_table   = "projects"
+  foreign_columns = ["tenant_id", "id"]
+
+[[boilingseed.foreign_keys]]
+  name            = "milestones_project_fkey"
+  table           = "milestones"
+  columns         = ["tenant_id", "project_id"]
+  foreign_table   = "projects"
+  foreign_columns = ["tenant_id", "id"]
 `
 )
 
@@ -112,6 +209,31 @@ func TestBoilingSeedIntegration(t *testing.T) {
 	t.Run("SeederExecution", suite.TestSeederExecution)
 	t.Run("CustomSeederFunctions", suite.TestCustomSeederFunctions)
 	t.Run("ForeignKeyRelationships", suite.TestForeignKeyRelationships)
+	t.Run("SelfReferencingTables", suite.TestSelfReferencingTables)
+	t.Run("CyclicForeignKeys", suite.TestCyclicForeignKeys)
+	t.Run("DeterministicSeeding", suite.TestDeterministicSeeding)
+	t.Run("TransactionalSeeding", suite.TestTransactionalSeeding)
+	t.Run("ErrorCollection", suite.TestErrorCollection)
+	t.Run("BatchInserts", suite.TestBatchInserts)
+	t.Run("ParentsFromRun", suite.TestParentsFromRun)
+	t.Run("SelectiveSeeding", suite.TestSelectiveSeeding)
+	t.Run("Factories", suite.TestFactories)
+	t.Run("ColumnGenerators", suite.TestColumnGenerators)
+	t.Run("UniqueConstraints", suite.TestUniqueConstraints)
+	t.Run("CheckConstraints", suite.TestCheckConstraints)
+	t.Run("NullableForeignKeys", suite.TestNullableForeignKeys)
+	t.Run("CompositeForeignKeys", suite.TestCompositeForeignKeys)
+	t.Run("NullableCompositeForeignKeys", suite.TestNullableCompositeForeignKeys)
+	t.Run("AssociationTables", suite.TestAssociationTables)
+	t.Run("RelationshipDistributions", suite.TestRelationshipDistributions)
+	t.Run("ManyToManyLinks", suite.TestManyToManyLinks)
+	t.Run("Fixtures", suite.TestFixtures)
+	t.Run("LoggerAndProgress", suite.TestLoggerAndProgress)
+	t.Run("Report", suite.TestReport)
+	t.Run("RandomWithContext", suite.TestRandomWithContext)
+	t.Run("InsertHooks", suite.TestInsertHooks)
+	t.Run("UpsertTables", suite.TestUpsertTables)
+	t.Run("CancelledRun", suite.TestCancelledRun)
 	t.Run("ConfigurationOptions", suite.TestConfigurationOptions)
 }
 
@@ -485,7 +607,7 @@ func main() {
 	}
 
 	fmt.Println("Starting seeder...")
-	if err := seeder.Run(ctx, db); err != nil {
+	if _, err := seeder.Run(ctx, db); err != nil {
 		log.Fatal("Seeder failed:", err)
 	}
 	fmt.Println("Seeding completed successfully!")
@@ -628,7 +750,7 @@ func main() {
 	}
 
 	fmt.Println("Running seeder with custom functions...")
-	if err := seeder.Run(ctx, db); err != nil {
+	if _, err := seeder.Run(ctx, db); err != nil {
 		log.Fatal("Seeder failed:", err)
 	}
 
@@ -756,7 +878,7 @@ func main() {
 	}
 
 	fmt.Println("Testing foreign key relationships...")
-	if err := seeder.Run(ctx, db); err != nil {
+	if _, err := seeder.Run(ctx, db); err != nil {
 		log.Fatal("Seeder failed:", err)
 	}
 
@@ -820,72 +942,2849 @@ func main() {
 	}
 }
 
-func (s *IntegrationTestSuite) TestConfigurationOptions(t *testing.T) {
-	// Test different configuration options
-	customOutputDir := filepath.Join(s.projectDir, "custom_seeds")
+func (s *IntegrationTestSuite) TestSelfReferencingTables(t *testing.T) {
+	// Categories reference their parent category, so they should be seeded as a tree
+	testProgram := `package main
 
-	// Test custom output directory and package name
-	if err := s.runCommand(s.binPath,
-		"-o", "custom_seeds",
-		"-p", "customseeds",
-		"sqlite3"); err != nil {
-		t.Fatalf("Failed to generate with custom config: %v", err)
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math/rand"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
 	}
+	defer db.Close()
 
-	// Verify custom output directory was created
-	if _, err := os.Stat(customOutputDir); os.IsNotExist(err) {
-		t.Error("Custom output directory was not created")
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	// Clear existing data
+	for _, table := range []string{"author_categories", "book_contributors", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
 	}
 
-	// Verify files were generated in custom directory
-	expectedFiles := []string{
-		"boilingseed_main.go",
-		"authors.go",
-		"books.go",
-		"categories.go",
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		MinCategoriesToSeed:     2,
+		CategoriesTreeDepth:     3,
+		CategoriesTreeBranching: 2,
+		RandomCategory: func() (*models.Category, error) {
+			return &models.Category{
+				Name: fmt.Sprintf("Category_%d", rand.Int63()),
+			}, nil
+		},
 	}
 
-	for _, file := range expectedFiles {
-		path := filepath.Join(customOutputDir, file)
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			t.Errorf("Expected file %s was not generated in custom directory", file)
+	if _, err := seeder.Run(ctx, db); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	categories, err := models.Categories().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load categories:", err)
+	}
+
+	byID := map[int64]*models.Category{}
+	for _, category := range categories {
+		byID[category.ID.Int64] = category
+	}
+
+	levels := map[int]int{}
+	for _, category := range categories {
+		depth := 0
+		for c := category; c.ParentID.Valid; c = byID[c.ParentID.Int64] {
+			if byID[c.ParentID.Int64] == nil {
+				log.Fatalf("Category %d has an unknown parent %d", c.ID.Int64, c.ParentID.Int64)
+			}
+			depth++
 		}
+		levels[depth]++
 	}
 
-	// Verify package name was changed
-	mainFile := filepath.Join(customOutputDir, "boilingseed_main.go")
-	content, err := os.ReadFile(mainFile)
+	fmt.Printf("Levels: %d roots, %d children, %d grandchildren\n", levels[0], levels[1], levels[2])
+	if len(categories) != 14 || levels[0] != 2 || levels[1] != 4 || levels[2] != 8 {
+		log.Fatalf("Unexpected tree shape: %v", levels)
+	}
+
+	fmt.Println("Self referencing tables test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "self_ref_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create self referencing test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "self_ref_demo.go")
 	if err != nil {
-		t.Fatalf("Failed to read main file: %v", err)
+		t.Fatalf("Failed to run self referencing test: %v\nOutput: %s", err, output)
 	}
 
-	if !strings.Contains(string(content), "package customseeds") {
-		t.Error("Package name was not changed to customseeds")
+	if !strings.Contains(output, "Self referencing tables test passed!") {
+		t.Errorf("Self referencing tables test failed. Output: %s", output)
 	}
+}
 
-	// Test wipe option
-	// First create a dummy file in the directory
-	dummyFile := filepath.Join(customOutputDir, "dummy.txt")
-	if err := os.WriteFile(dummyFile, []byte("dummy"), 0o644); err != nil {
-		t.Fatalf("Failed to create dummy file: %v", err)
+func (s *IntegrationTestSuite) TestCyclicForeignKeys(t *testing.T) {
+	// Teams reference their captain and players reference their team.
+	// The nullable captain should be backfilled after both tables are seeded.
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+	"github.com/aarondl/null/v8"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
 	}
+	defer db.Close()
 
-	// Run with wipe option
-	if err := s.runCommand(s.binPath,
-		"-o", "custom_seeds",
-		"-p", "customseeds",
-		"--wipe",
-		"sqlite3"); err != nil {
-		t.Fatalf("Failed to generate with wipe option: %v", err)
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	// Clear existing data
+	for _, table := range []string{"players", "teams"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
 	}
 
-	// Verify dummy file was removed
-	if _, err := os.Stat(dummyFile); !os.IsNotExist(err) {
-		t.Error("Dummy file was not removed by wipe option")
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		MinTeamsToSeed:   3,
+		MinPlayersToSeed: 6,
 	}
 
-	// Verify seed files were still generated
-	if _, err := os.Stat(mainFile); os.IsNotExist(err) {
-		t.Error("Main file was not regenerated after wipe")
+	if _, err := seeder.Run(ctx, db); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	teams, err := models.Teams().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load teams:", err)
+	}
+
+	if len(teams) != 3 {
+		log.Fatalf("Expected 3 teams, got %d", len(teams))
+	}
+
+	for _, team := range teams {
+		if !team.CaptainID.Valid {
+			log.Fatalf("Team %d has no captain", team.ID.Int64)
+		}
+
+		if _, err := models.FindPlayer(ctx, db, team.CaptainID); err != nil {
+			log.Fatalf("Failed to find captain %d: %v", team.CaptainID.Int64, err)
+		}
+	}
+
+	players, err := models.Players().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load players:", err)
+	}
+
+	for _, player := range players {
+		if _, err := models.FindTeam(ctx, db, null.Int64From(player.TeamID)); err != nil {
+			log.Fatalf("Failed to find team %d: %v", player.TeamID, err)
+		}
+	}
+
+	fmt.Println("Cyclic foreign keys test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "cycle_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create cyclic foreign keys test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "cycle_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run cyclic foreign keys test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Cyclic foreign keys test passed!") {
+		t.Errorf("Cyclic foreign keys test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestDeterministicSeeding(t *testing.T) {
+	// Seeding an empty database twice with the same seed should insert the same rows
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+)
+
+func seed(db *sql.DB) string {
+	// Clear existing data and reset the autoincrement counters
+	for _, table := range []string{"players", "teams", "sqlite_sequence"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	seeder := seeds.Seeder{
+		MinTeamsToSeed:   3,
+		MinPlayersToSeed: 8,
+		Seed:             42,
+	}
+
+	if _, err := seeder.Run(context.Background(), db); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	var dump strings.Builder
+	queries := []string{
+		"SELECT id, name, captain_id FROM teams ORDER BY id",
+		"SELECT id, name, team_id FROM players ORDER BY id",
+	}
+	for _, query := range queries {
+		rows, err := db.Query(query)
+		if err != nil {
+			log.Fatal("Failed to query rows:", err)
+		}
+
+		cols, _ := rows.Columns()
+		for rows.Next() {
+			values := make([]any, len(cols))
+			pointers := make([]any, len(cols))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err := rows.Scan(pointers...); err != nil {
+				log.Fatal("Failed to scan row:", err)
+			}
+			fmt.Fprintln(&dump, values...)
+		}
+		rows.Close()
+	}
+
+	return dump.String()
+}
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	first := seed(db)
+	second := seed(db)
+
+	if first != second {
+		log.Fatalf("Seeding with the same seed gave different rows:\n%s\n---\n%s", first, second)
+	}
+
+	fmt.Println("Deterministic seeding test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "deterministic_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create deterministic seeding test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "deterministic_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run deterministic seeding test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Deterministic seeding test passed!") {
+		t.Errorf("Deterministic seeding test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestTransactionalSeeding(t *testing.T) {
+	// RunInTx should seed sequentially in a transaction and roll back on failure
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Clear existing data
+	for _, table := range []string{"players", "teams"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		MinTeamsToSeed:   2,
+		MinPlayersToSeed: 5,
+	}
+
+	if _, err := seeder.RunInTx(ctx, db); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	teamCount, err := models.Teams().Count(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to count teams:", err)
+	}
+	playerCount, err := models.Players().Count(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to count players:", err)
+	}
+	if teamCount != 2 || playerCount != 5 {
+		log.Fatalf("Expected 2 teams and 5 players, got %d and %d", teamCount, playerCount)
+	}
+
+	// A failing seed should leave the database untouched
+	errSeed := errors.New("stop seeding")
+	seeder.AfterPlayersAdded = func(ctx context.Context) error {
+		return errSeed
+	}
+
+	if _, err := seeder.RunInTx(ctx, db); !errors.Is(err, errSeed) {
+		log.Fatalf("Expected the seed to fail, got %v", err)
+	}
+
+	teamCount, err = models.Teams().Count(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to count teams:", err)
+	}
+	playerCount, err = models.Players().Count(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to count players:", err)
+	}
+	if teamCount != 2 || playerCount != 5 {
+		log.Fatalf("Expected the failed seed to be rolled back, got %d teams and %d players", teamCount, playerCount)
+	}
+
+	fmt.Println("Transactional seeding test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "tx_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create transactional seeding test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "tx_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run transactional seeding test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Transactional seeding test passed!") {
+		t.Errorf("Transactional seeding test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestErrorCollection(t *testing.T) {
+	// The failures of every table should be returned, not only the first one
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	errAuthors := errors.New("authors failed")
+	errTeams := errors.New("teams failed")
+
+	seeder := seeds.Seeder{
+		MinAuthorsToSeed: 1,
+		MinTeamsToSeed:   1,
+		MinPlayersToSeed: 1,
+		RandomAuthor: func() (*models.Author, error) {
+			return &models.Author{
+				Name:  fmt.Sprintf("Author_%d", rand.Int63()),
+				Email: fmt.Sprintf("author_%d@example.com", rand.Int63()),
+			}, nil
+		},
+		AfterAuthorsAdded: func(ctx context.Context) error {
+			return errAuthors
+		},
+		AfterTeamsAdded: func(ctx context.Context) error {
+			return errTeams
+		},
+	}
+
+	for _, sequential := range []bool{false, true} {
+		seeder.Sequential = sequential
+		_, err = seeder.Run(ctx, db)
+		if !errors.Is(err, errAuthors) || !errors.Is(err, errTeams) {
+			log.Fatalf("Expected both failures to be returned (sequential %v), got %v", sequential, err)
+		}
+
+		var seedErr *seeds.SeedError
+		if !errors.As(err, &seedErr) || (seedErr.Table != "authors" && seedErr.Table != "teams") {
+			log.Fatalf("Expected a SeedError for a failed table, got %v", err)
+		}
+
+		fmt.Println(err)
+	}
+
+	// RunInTx rolls back on the first error, so it stops there
+	_, err = seeder.RunInTx(ctx, db)
+	if err == nil || (errors.Is(err, errAuthors) && errors.Is(err, errTeams)) {
+		log.Fatalf("Expected RunInTx to stop at the first failure, got %v", err)
+	}
+
+	fmt.Println("Error collection test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "errors_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create error collection test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "errors_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run error collection test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Error collection test passed!") {
+		t.Errorf("Error collection test failed. Output: %s", output)
+	}
+
+	// Players depend on teams, so they should be skipped instead of failing,
+	// whether the tables are seeded concurrently or sequentially
+	if strings.Contains(output, "seeding players") {
+		t.Errorf("Players should have been skipped. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestBatchInserts(t *testing.T) {
+	// Rows inserted in batches should get their primary keys back
+	// so that dependent tables can reference them
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/aarondl/null/v8"
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	authors, categories, books := 0, 0, 0
+
+	seeder := seeds.Seeder{
+		Sequential:          true,
+		MinAuthorsToSeed:    10,
+		AuthorsBatchSize:    4,
+		MinCategoriesToSeed: 2,
+		CategoriesBatchSize: 10,
+		MinBooksToSeed:      12,
+		BooksBatchSize:      5,
+		RandomAuthor: func() (*models.Author, error) {
+			authors++
+			o := &models.Author{
+				Name:  fmt.Sprintf("Author_%d", authors),
+				Email: fmt.Sprintf("batch_author_%d@example.com", authors),
+			}
+			// Rows that set a column with a default are batched separately
+			if authors%3 == 0 {
+				o.Bio = null.StringFrom("bio")
+			}
+			return o, nil
+		},
+		RandomCategory: func() (*models.Category, error) {
+			categories++
+			return &models.Category{Name: fmt.Sprintf("Batch_Category_%d", categories)}, nil
+		},
+		RandomBook: func() (*models.Book, error) {
+			books++
+			return &models.Book{
+				Title: fmt.Sprintf("Book_%d", books),
+				Isbn:  fmt.Sprintf("BATCH-ISBN-%d", books),
+				Pages: null.Int64From(int64(books)),
+			}, nil
+		},
+	}
+
+	if _, err := seeder.Run(ctx, db); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	authorCount, err := models.Authors().Count(ctx, db)
+	if err != nil || authorCount != 10 {
+		log.Fatalf("Expected 10 authors, got %d (%v)", authorCount, err)
+	}
+
+	withBio, err := models.Authors(models.AuthorWhere.Bio.EQ(null.StringFrom("bio"))).Count(ctx, db)
+	if err != nil || withBio != 3 {
+		log.Fatalf("Expected 3 authors with a bio, got %d (%v)", withBio, err)
+	}
+
+	allBooks, err := models.Books().All(ctx, db)
+	if err != nil || len(allBooks) != 12 {
+		log.Fatalf("Expected 12 books, got %d (%v)", len(allBooks), err)
+	}
+
+	for _, book := range allBooks {
+		if !book.Pages.Valid {
+			log.Fatalf("Book %d lost its pages", book.ID)
+		}
+		exists, err := models.AuthorExists(ctx, db, null.Int64From(book.AuthorID))
+		if err != nil || !exists {
+			log.Fatalf("Book %v references a missing author %d (%v)", book.ID, book.AuthorID, err)
+		}
+	}
+
+	fmt.Println("Batch inserts test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "batch_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create batch inserts test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "batch_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run batch inserts test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Batch inserts test passed!") {
+		t.Errorf("Batch inserts test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestParentsFromRun(t *testing.T) {
+	// Children should only reference parents inserted in the same run,
+	// not rows that were already in the database
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+
+	existing := &models.Author{Name: "Existing", Email: "existing_parent@example.com"}
+	if err := existing.Insert(ctx, db, boil.Infer()); err != nil {
+		log.Fatal("Failed to insert existing author:", err)
+	}
+
+	authors, categories, books := 0, 0, 0
+	seeder := seeds.Seeder{
+		Sequential:          true,
+		MinAuthorsToSeed:    3,
+		MinCategoriesToSeed: 2,
+		MinBooksToSeed:      9,
+		RandomAuthor: func() (*models.Author, error) {
+			authors++
+			return &models.Author{
+				Name:  fmt.Sprintf("Author_%d", authors),
+				Email: fmt.Sprintf("run_author_%d@example.com", authors),
+			}, nil
+		},
+		RandomCategory: func() (*models.Category, error) {
+			categories++
+			return &models.Category{Name: fmt.Sprintf("Run_Category_%d", categories)}, nil
+		},
+		RandomBook: func() (*models.Book, error) {
+			books++
+			return &models.Book{
+				Title: fmt.Sprintf("Book_%d", books),
+				Isbn:  fmt.Sprintf("RUN-ISBN-%d", books),
+			}, nil
+		},
+	}
+
+	if _, err := seeder.Run(ctx, db); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	count, err := models.Books(models.BookWhere.AuthorID.EQ(existing.ID.Int64)).Count(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to count books:", err)
+	}
+	if count != 0 {
+		log.Fatalf("Expected no books to reference the existing author, got %d", count)
+	}
+
+	// Half of the new books should reference authors that existed before the run
+	before, err := models.Authors().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load authors:", err)
+	}
+	existingIDs := map[int64]bool{}
+	for _, author := range before {
+		existingIDs[author.ID.Int64] = true
+	}
+
+	lastBook, err := models.Books(qm.OrderBy("id DESC")).One(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load last book:", err)
+	}
+
+	seeder.MinAuthorsToSeed = 2
+	seeder.MinBooksToSeed = 8
+	seeder.ExistingParentsRatio = 0.5
+	if _, err := seeder.Run(ctx, db); err != nil {
+		log.Fatal("Mixed seeder failed:", err)
+	}
+
+	newBooks, err := models.Books(models.BookWhere.ID.GT(lastBook.ID)).All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load new books:", err)
+	}
+
+	fromExisting := 0
+	for _, book := range newBooks {
+		if existingIDs[book.AuthorID] {
+			fromExisting++
+		}
+	}
+	if len(newBooks) != 8 || fromExisting != 4 {
+		log.Fatalf("Expected 4 of 8 new books to reference existing authors, got %d of %d", fromExisting, len(newBooks))
+	}
+
+	fmt.Println("Parents from run test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "parents_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create parents from run test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "parents_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run parents from run test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Parents from run test passed!") {
+		t.Errorf("Parents from run test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestSelectiveSeeding(t *testing.T) {
+	// RunTables should seed the requested tables and their required parents only
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+
+	counts := func() map[string]int64 {
+		result := map[string]int64{}
+		for _, table := range []string{"authors", "categories", "books", "book_tags", "teams", "players"} {
+			var count int64
+			if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+				log.Fatalf("Failed to count %s: %v", table, err)
+			}
+			result[table] = count
+		}
+		return result
+	}
+
+	authors, categories, books := 0, 0, 0
+	seeder := seeds.Seeder{
+		MinAuthorsToSeed:    2,
+		MinCategoriesToSeed: 2,
+		MinBooksToSeed:      3,
+		MinTeamsToSeed:      2,
+		MinPlayersToSeed:    2,
+		RandomAuthor: func() (*models.Author, error) {
+			authors++
+			return &models.Author{
+				Name:  fmt.Sprintf("Author_%d", authors),
+				Email: fmt.Sprintf("selective_author_%d@example.com", authors),
+			}, nil
+		},
+		RandomCategory: func() (*models.Category, error) {
+			categories++
+			return &models.Category{Name: fmt.Sprintf("Selective_Category_%d", categories)}, nil
+		},
+		RandomBook: func() (*models.Book, error) {
+			books++
+			return &models.Book{
+				Title: fmt.Sprintf("Book_%d", books),
+				Isbn:  fmt.Sprintf("SELECTIVE-ISBN-%d", books),
+			}, nil
+		},
+	}
+
+	before := counts()
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err != nil {
+		log.Fatal("RunTables failed:", err)
+	}
+	after := counts()
+
+	expected := map[string]int64{"authors": 2, "categories": 2, "books": 3}
+	for table, count := range after {
+		if added := count - before[table]; added != expected[table] {
+			log.Fatalf("Expected %d %s to be added, got %d", expected[table], table, added)
+		}
+	}
+
+	if _, err := seeder.RunTables(ctx, db, "unknown"); err == nil {
+		log.Fatal("Expected an error for an unknown table")
+	}
+
+	fmt.Println("Selective seeding test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "selective_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create selective seeding test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "selective_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run selective seeding test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Selective seeding test passed!") {
+		t.Errorf("Selective seeding test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestFactories(t *testing.T) {
+	// The New functions should insert a single model with its required parents
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+
+	// The author and category are created automatically
+	book, err := seeds.NewBook(ctx, db, func(b *models.Book) {
+		b.Title = "Factory Book"
+		b.Isbn = "FACTORY-ISBN-1"
+	})
+	if err != nil {
+		log.Fatal("NewBook failed:", err)
+	}
+
+	if book.Title != "Factory Book" || !book.ID.Valid {
+		log.Fatalf("Expected an inserted book with the overridden title, got %+v", book)
+	}
+
+	authors, err := models.Authors().Count(ctx, db)
+	if err != nil || authors != 1 {
+		log.Fatalf("Expected 1 author to be created, got %d (%v)", authors, err)
+	}
+	categories, err := models.Categories().Count(ctx, db)
+	if err != nil || categories != 1 {
+		log.Fatalf("Expected 1 category to be created, got %d (%v)", categories, err)
+	}
+
+	// An existing author is used instead of creating one
+	author, err := seeds.NewAuthor(ctx, db, func(a *models.Author) {
+		a.Email = "factory_author@example.com"
+	})
+	if err != nil {
+		log.Fatal("NewAuthor failed:", err)
+	}
+
+	second, err := seeds.NewBook(ctx, db, func(b *models.Book) {
+		b.Isbn = "FACTORY-ISBN-2"
+		b.AuthorID = author.ID.Int64
+		b.CategoryID = book.CategoryID
+	})
+	if err != nil {
+		log.Fatal("NewBook with parents failed:", err)
+	}
+
+	if second.AuthorID != author.ID.Int64 || second.CategoryID != book.CategoryID {
+		log.Fatalf("Expected the given parents to be used, got %+v", second)
+	}
+
+	authors, err = models.Authors().Count(ctx, db)
+	if err != nil || authors != 2 {
+		log.Fatalf("Expected 2 authors, got %d (%v)", authors, err)
+	}
+
+	fmt.Println("Factories test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "factories_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create factories test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "factories_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run factories test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Factories test passed!") {
+		t.Errorf("Factories test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestColumnGenerators(t *testing.T) {
+	// Columns configured in [boilingseed.generators] should get realistic values
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{Sequential: true, MinAuthorsToSeed: 20}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Authors); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	authors, err := models.Authors().All(ctx, db)
+	if err != nil || len(authors) != 20 {
+		log.Fatalf("Expected 20 authors, got %d (%v)", len(authors), err)
+	}
+	for _, author := range authors {
+		if !strings.HasSuffix(author.Email, "@example.com") {
+			log.Fatalf("Expected a generated email, got %q", author.Email)
+		}
+		if !strings.Contains(author.Name, " ") {
+			log.Fatalf("Expected a generated name, got %q", author.Name)
+		}
+		// bio is not configured, but follows a naming convention
+		if !author.Bio.Valid || !strings.HasSuffix(author.Bio.String, ".") {
+			log.Fatalf("Expected a generated lorem bio, got %v", author.Bio)
+		}
+	}
+
+	book, err := seeds.NewBook(ctx, db)
+	if err != nil {
+		log.Fatal("NewBook failed:", err)
+	}
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	if !book.PublishedDate.Valid || book.PublishedDate.Time.Before(from) || book.PublishedDate.Time.After(to) {
+		log.Fatalf("Expected a published date in 2020-2024, got %v", book.PublishedDate)
+	}
+
+	fmt.Println("Column generators test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "generators_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create column generators test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "generators_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run column generators test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Column generators test passed!") {
+		t.Errorf("Column generators test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestUniqueConstraints(t *testing.T) {
+	// UNIQUE columns should get values that were not used yet in the run
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	clear := func() {
+		for _, table := range []string{"author_categories", "book_contributors", "book_tags", "books", "authors", "categories"} {
+			if _, err := db.Exec("DELETE FROM " + table); err != nil {
+				log.Fatalf("Failed to clear %s: %v", table, err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+
+	// default random values, with a suffix for the UNIQUE names and isbns
+	clear()
+	seeder := seeds.Seeder{
+		Sequential:          true,
+		MinAuthorsToSeed:    50,
+		MinCategoriesToSeed: 200,
+		MinBooksToSeed:      200,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+	if count, _ := models.Categories().Count(ctx, db); count != 200 {
+		log.Fatalf("Expected 200 categories, got %d", count)
+	}
+
+	// a duplicate is retried
+	clear()
+	calls := 0
+	seeder = seeds.Seeder{
+		Sequential:       true,
+		Retries:          1,
+		MinAuthorsToSeed: 3,
+		RandomAuthor: func() (*models.Author, error) {
+			calls++
+			email := fmt.Sprintf("unique_%d@example.com", calls)
+			if calls == 2 {
+				email = "unique_1@example.com"
+			}
+			return &models.Author{Name: "Unique", Email: email}, nil
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Authors); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+	if count, _ := models.Authors().Count(ctx, db); count != 3 || calls != 4 {
+		log.Fatalf("Expected 3 authors from 4 calls, got %d from %d", count, calls)
+	}
+
+	// a value that is always the same fails after the retries
+	clear()
+	seeder = seeds.Seeder{
+		Sequential:       true,
+		Retries:          2,
+		MinAuthorsToSeed: 3,
+		RandomAuthor: func() (*models.Author, error) {
+			return &models.Author{Name: "Same", Email: "same@example.com"}, nil
+		},
+	}
+	_, err = seeder.RunTables(ctx, db, models.TableNames.Authors)
+	if err == nil || !strings.Contains(err.Error(), "UNIQUE constraint on authors.email after 2 retries") {
+		log.Fatalf("Expected a UNIQUE constraint error, got %v", err)
+	}
+
+	fmt.Println("Unique constraints test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "unique_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create unique constraints test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "unique_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run unique constraints test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Unique constraints test passed!") {
+		t.Errorf("Unique constraints test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestCheckConstraints(t *testing.T) {
+	// Columns with a range or in generator should stay inside their CHECK constraints
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "book_tags", "books", "authors", "categories", "players", "teams"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		Sequential:       true,
+		MinBooksToSeed:   100,
+		MinPlayersToSeed: 100,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books, models.TableNames.Players); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	books, err := models.Books().All(ctx, db)
+	if err != nil || len(books) < 100 {
+		log.Fatalf("Expected 100 books, got %d (%v)", len(books), err)
+	}
+	for _, book := range books {
+		if !book.Pages.Valid || book.Pages.Int64 < 50 || book.Pages.Int64 > 900 {
+			log.Fatalf("Expected pages between 50 and 900, got %v", book.Pages)
+		}
+	}
+
+	positions := map[string]int{}
+	players, err := models.Players().All(ctx, db)
+	if err != nil || len(players) < 100 {
+		log.Fatalf("Expected 100 players, got %d (%v)", len(players), err)
+	}
+	for _, player := range players {
+		positions[player.Position.String]++
+	}
+	for position := range positions {
+		switch position {
+		case "goalkeeper", "defender", "midfielder", "forward":
+		default:
+			log.Fatalf("Unexpected position %q", position)
+		}
+	}
+	if len(positions) != 4 {
+		log.Fatalf("Expected every position to be used, got %v", positions)
+	}
+
+	fmt.Println("Check constraints test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "checks_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create check constraints test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "checks_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run check constraints test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Check constraints test passed!") {
+		t.Errorf("Check constraints test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestNullableForeignKeys(t *testing.T) {
+	// A share of the optional foreign keys should be left NULL
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aarondl/null/v8"
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db?_pragma=foreign_keys(1)")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	clear := func() {
+		for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+			if _, err := db.Exec("DELETE FROM " + table); err != nil {
+				log.Fatalf("Failed to clear %s: %v", table, err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+
+	clear()
+	seeder := seeds.Seeder{
+		Sequential:              true,
+		MinAuthorsToSeed:        4,
+		MinReviewsToSeed:        40,
+		ReviewReviewerNullRatio: 0.25,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Authors, models.TableNames.Reviews); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	withoutReviewer, err := models.Reviews(models.ReviewWhere.ReviewerID.IsNull()).Count(ctx, db)
+	if err != nil || withoutReviewer != 10 {
+		log.Fatalf("Expected 10 reviews without a reviewer, got %d (%v)", withoutReviewer, err)
+	}
+
+	// with a ratio of 1, reviews do not wait for the authors
+	clear()
+	reviewsAdded := make(chan struct{})
+	seeder = seeds.Seeder{
+		MinAuthorsToSeed:        1,
+		MinReviewsToSeed:        5,
+		ReviewReviewerNullRatio: 1,
+		RandomAuthor: func() (*models.Author, error) {
+			select {
+			case <-reviewsAdded:
+				return &models.Author{Name: "Late", Email: "late@example.com"}, nil
+			case <-time.After(10 * time.Second):
+				return nil, errors.New("reviews waited for the authors")
+			}
+		},
+		AfterReviewsAdded: func(ctx context.Context) error {
+			close(reviewsAdded)
+			return nil
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Authors, models.TableNames.Reviews); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	withoutReviewer, err = models.Reviews(models.ReviewWhere.ReviewerID.IsNull()).Count(ctx, db)
+	if err != nil || withoutReviewer != 5 {
+		log.Fatalf("Expected 5 reviews without a reviewer, got %d (%v)", withoutReviewer, err)
+	}
+
+	// authors are not selected, so no review can reference one,
+	// even if the random review points to a missing author
+	clear()
+	seeder = seeds.Seeder{
+		Sequential:       true,
+		MinReviewsToSeed: 30,
+		RandomReview: func() (*models.Review, error) {
+			return &models.Review{Body: "Dangling", ReviewerID: null.Int64From(424242)}, nil
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Reviews); err != nil {
+		log.Fatal("Seeder failed without authors:", err)
+	}
+
+	withoutReviewer, err = models.Reviews(models.ReviewWhere.ReviewerID.IsNull()).Count(ctx, db)
+	if err != nil || withoutReviewer != 30 {
+		log.Fatalf("Expected 30 reviews without a reviewer, got %d (%v)", withoutReviewer, err)
+	}
+
+	clear()
+	fmt.Println("Nullable foreign keys test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "nullable_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create nullable foreign keys test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "nullable_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run nullable foreign keys test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Nullable foreign keys test passed!") {
+		t.Errorf("Nullable foreign keys test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestCompositeForeignKeys(t *testing.T) {
+	// Every column of a composite foreign key should come from the same parent row
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db?_pragma=foreign_keys(1)")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"tasks", "projects", "tenants"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	projects := int64(0)
+	seeder := seeds.Seeder{
+		Sequential:        true,
+		MinTenantsToSeed:  3,
+		MinProjectsToSeed: 5,
+		MinTasksToSeed:    20,
+		RandomProject: func() (*models.Project, error) {
+			projects++
+			return &models.Project{ID: projects, Name: fmt.Sprintf("Project_%d", projects)}, nil
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Tasks); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	tasks, err := models.Tasks().All(ctx, db)
+	if err != nil || len(tasks) != 20 {
+		log.Fatalf("Expected 20 tasks, got %d (%v)", len(tasks), err)
+	}
+
+	for _, task := range tasks {
+		exists, err := models.ProjectExists(ctx, db, task.TenantID, task.ProjectID)
+		if err != nil || !exists {
+			log.Fatalf("Task %d references a missing project (%d, %d): %v", task.ID, task.TenantID, task.ProjectID, err)
+		}
+	}
+
+	task, err := seeds.NewTask(ctx, db)
+	if err != nil {
+		log.Fatal("NewTask failed:", err)
+	}
+	if exists, _ := models.ProjectExists(ctx, db, task.TenantID, task.ProjectID); !exists {
+		log.Fatalf("NewTask references a missing project (%d, %d)", task.TenantID, task.ProjectID)
+	}
+
+	// existing projects are loaded for the composite key as well
+	before, err := models.Projects().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load projects:", err)
+	}
+	existing := map[[2]int64]bool{}
+	for _, project := range before {
+		existing[[2]int64{project.TenantID, project.ID}] = true
+	}
+
+	seeder.MinTasksToSeed = 10
+	seeder.ExistingParentsRatio = 1
+	report, err := seeder.RunTables(ctx, db, models.TableNames.Tasks)
+	if err != nil {
+		log.Fatal("Seeder with existing parents failed:", err)
+	}
+	for _, task := range report.Rows.Tasks {
+		if !existing[[2]int64{task.TenantID, task.ProjectID}] {
+			log.Fatalf("Expected new tasks to reference a project from before the run, got (%d, %d)", task.TenantID, task.ProjectID)
+		}
+	}
+
+	fmt.Println("Composite foreign keys test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "composite_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create composite foreign keys test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "composite_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run composite foreign keys test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Composite foreign keys test passed!") {
+		t.Errorf("Composite foreign keys test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestNullableCompositeForeignKeys(t *testing.T) {
+	// A composite foreign key with a nullable column gets a NullRatio named after
+	// the foreign table, and leaves only its nullable columns NULL
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db?_pragma=foreign_keys(1)")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	for _, sequential := range []bool{true, false} {
+		for _, table := range []string{"milestones", "tasks", "projects", "tenants"} {
+			if _, err := db.Exec("DELETE FROM " + table); err != nil {
+				log.Fatalf("Failed to clear %s: %v", table, err)
+			}
+		}
+
+		projects := int64(0)
+		seeder := seeds.Seeder{
+			Sequential:                sequential,
+			MinTenantsToSeed:          3,
+			MinProjectsToSeed:         5,
+			MinMilestonesToSeed:       20,
+			MilestoneProjectNullRatio: 0.25,
+			RandomProject: func() (*models.Project, error) {
+				projects++
+				return &models.Project{ID: projects, Name: fmt.Sprintf("Project_%d", projects)}, nil
+			},
+		}
+		if _, err := seeder.RunTables(ctx, db, models.TableNames.Milestones); err != nil {
+			log.Fatal("Seeder failed:", err)
+		}
+
+		milestones, err := models.Milestones().All(ctx, db)
+		if err != nil || len(milestones) != 20 {
+			log.Fatalf("Expected 20 milestones, got %d (%v)", len(milestones), err)
+		}
+
+		nulls := 0
+		for _, milestone := range milestones {
+			if !milestone.ProjectID.Valid {
+				nulls++
+				continue
+			}
+
+			exists, err := models.ProjectExists(ctx, db, milestone.TenantID, milestone.ProjectID.Int64)
+			if err != nil || !exists {
+				log.Fatalf("Milestone %d references a missing project (%d, %d): %v", milestone.ID, milestone.TenantID, milestone.ProjectID.Int64, err)
+			}
+		}
+
+		if nulls != 5 {
+			log.Fatalf("Expected 5 milestones without a project (sequential %v), got %d", sequential, nulls)
+		}
+	}
+
+	fmt.Println("Nullable composite foreign keys test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "nullable_composite_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create nullable composite foreign keys test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "nullable_composite_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run nullable composite foreign keys test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Nullable composite foreign keys test passed!") {
+		t.Errorf("Nullable composite foreign keys test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestAssociationTables(t *testing.T) {
+	// Rows of a table whose primary key is made of foreign keys should each link
+	// a different combination of parents, with the other columns from RandomX
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	roles := []string{"editor", "translator", "illustrator"}
+	contributors := 0
+	seeder := seeds.Seeder{
+		Sequential:                true,
+		MinAuthorsToSeed:          2,
+		MinCategoriesToSeed:       2,
+		MinBooksToSeed:            3,
+		MinBookContributorsToSeed: 20,
+		RandomBookContributor: func() (*models.BookContributor, error) {
+			contributors++
+			return &models.BookContributor{
+				Role:     roles[contributors%len(roles)],
+				JoinedAt: time.Now(),
+			}, nil
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.BookContributors); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	// 3 books, 2 authors and 2 categories only make 12 combinations
+	rows, err := models.BookContributors().All(ctx, db)
+	if err != nil || len(rows) != 12 {
+		log.Fatalf("Expected 12 book contributors, got %d (%v)", len(rows), err)
+	}
+
+	perBook := map[int64]int{}
+	for _, row := range rows {
+		if row.Role == "" {
+			log.Fatalf("Book contributor (%d, %d, %d) has no role", row.BookID, row.AuthorID, row.CategoryID)
+		}
+		perBook[row.BookID]++
+	}
+
+	for book, count := range perBook {
+		if count != 4 {
+			log.Fatalf("Expected every combination for book %d, got %d rows", book, count)
+		}
+	}
+
+	fmt.Println("Association tables test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "association_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create association tables test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "association_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run association tables test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Association tables test passed!") {
+		t.Errorf("Association tables test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestRelationshipDistributions(t *testing.T) {
+	// Parents should get a number of children in the configured range,
+	// and a Zipf distribution should give most of them few
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		Sequential:                 true,
+		Seed:                       42,
+		MinAuthorsToSeed:           30,
+		MinCategoriesToSeed:        1,
+		BooksPerAuthorMin:          1,
+		BooksPerAuthorMax:          10,
+		BooksPerAuthorDistribution: seeds.DistributionZipf,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	books, err := models.Books().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to load books:", err)
+	}
+
+	perAuthor := map[int64]int{}
+	for _, book := range books {
+		perAuthor[book.AuthorID]++
+	}
+	if len(perAuthor) != 30 {
+		log.Fatalf("Expected books for 30 authors, got %d", len(perAuthor))
+	}
+
+	few, many := 0, 0
+	for author, count := range perAuthor {
+		if count < 1 || count > 10 {
+			log.Fatalf("Author %d has %d books, expected between 1 and 10", author, count)
+		}
+		if count <= 2 {
+			few++
+		}
+		if count >= 5 {
+			many++
+		}
+	}
+	if few <= many || many == 0 {
+		log.Fatalf("Expected most authors to have few books and some to have many, got %d with few and %d with many", few, many)
+	}
+
+	// MinBooksToSeed tops the numbers drawn up to the maximum, and no further
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+	seeder = seeds.Seeder{
+		Sequential:          true,
+		MinAuthorsToSeed:    5,
+		MinCategoriesToSeed: 1,
+		MinBooksToSeed:      15,
+		BooksPerAuthorMax:   3,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	books, err = models.Books().All(ctx, db)
+	if err != nil || len(books) != 15 {
+		log.Fatalf("Expected 15 books, got %d (%v)", len(books), err)
+	}
+	perAuthor = map[int64]int{}
+	for _, book := range books {
+		perAuthor[book.AuthorID]++
+	}
+	for author, count := range perAuthor {
+		if count != 3 {
+			log.Fatalf("Author %d has %d books, expected 3", author, count)
+		}
+	}
+
+	seeder.MinBooksToSeed = 16
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err == nil || !strings.Contains(err.Error(), "BooksPerAuthorMax") {
+		log.Fatalf("Expected an error for more books than BooksPerAuthorMax allows, got %v", err)
+	}
+
+	// with two ranged parents, both are topped up to the larger of the numbers drawn
+	for _, table := range []string{"books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+	seeder = seeds.Seeder{
+		Sequential:          true,
+		MinAuthorsToSeed:    5,
+		MinCategoriesToSeed: 4,
+		BooksPerAuthorMin:   1,
+		BooksPerAuthorMax:   4,
+		BooksPerCategoryMin: 4,
+		BooksPerCategoryMax: 5,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err != nil {
+		log.Fatal("Seeder with two ranged parents failed:", err)
+	}
+
+	books, err = models.Books().All(ctx, db)
+	if err != nil || len(books) < 16 || len(books) > 20 {
+		log.Fatalf("Expected 16 to 20 books, got %d (%v)", len(books), err)
+	}
+	perAuthor = map[int64]int{}
+	perCategory := map[int64]int{}
+	for _, book := range books {
+		perAuthor[book.AuthorID]++
+		perCategory[book.CategoryID]++
+	}
+	for author, count := range perAuthor {
+		if count > 4 {
+			log.Fatalf("Author %d has %d books, expected at most 4", author, count)
+		}
+	}
+	for category, count := range perCategory {
+		if count < 4 || count > 5 {
+			log.Fatalf("Category %d has %d books, expected 4 to 5", category, count)
+		}
+	}
+
+	fmt.Println("Relationship distributions test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "distribution_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create relationship distributions test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "distribution_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run relationship distributions test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Relationship distributions test passed!") {
+		t.Errorf("Relationship distributions test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestManyToManyLinks(t *testing.T) {
+	// Join tables should get the exact count, density or number of links per side asked for
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	seed := func(seeder seeds.Seeder) {
+		for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+			if _, err := db.Exec("DELETE FROM " + table); err != nil {
+				log.Fatalf("Failed to clear %s: %v", table, err)
+			}
+		}
+
+		seeder.Sequential = true
+		seeder.MinAuthorsToSeed = 10
+		seeder.MinCategoriesToSeed = 8
+		if _, err := seeder.RunTables(ctx, db, "author_categories"); err != nil {
+			log.Fatal("Seeder failed:", err)
+		}
+	}
+
+	links := func() (map[int64]int, map[int64]int, int) {
+		rows, err := db.Query("SELECT author_id, category_id FROM author_categories")
+		if err != nil {
+			log.Fatal("Failed to query links:", err)
+		}
+		defer rows.Close()
+
+		perAuthor, perCategory, total := map[int64]int{}, map[int64]int{}, 0
+		for rows.Next() {
+			var author, category int64
+			if err := rows.Scan(&author, &category); err != nil {
+				log.Fatal("Failed to scan link:", err)
+			}
+			perAuthor[author]++
+			perCategory[category]++
+			total++
+		}
+
+		return perAuthor, perCategory, total
+	}
+
+	seed(seeds.Seeder{AuthorCategoriesCount: 25, AuthorCategoriesSkew: 1.5})
+	if _, _, total := links(); total != 25 {
+		log.Fatalf("Expected exactly 25 links, got %d", total)
+	}
+
+	seed(seeds.Seeder{AuthorCategoriesDensity: 0.5})
+	if _, _, total := links(); total != 40 {
+		log.Fatalf("Expected half of the 80 pairs to be linked, got %d", total)
+	}
+
+	seed(seeds.Seeder{
+		CategoriesPerAuthorMin: 2,
+		CategoriesPerAuthorMax: 3,
+		AuthorsPerCategoryMin:  3,
+	})
+	perAuthor, perCategory, _ := links()
+	if len(perAuthor) != 10 || len(perCategory) != 8 {
+		log.Fatalf("Expected every author and category to be linked, got %d and %d", len(perAuthor), len(perCategory))
+	}
+	for author, count := range perAuthor {
+		if count < 2 || count > 3 {
+			log.Fatalf("Author %d has %d categories, expected 2 or 3", author, count)
+		}
+	}
+	for category, count := range perCategory {
+		if count < 3 {
+			log.Fatalf("Category %d has %d authors, expected at least 3", category, count)
+		}
+	}
+
+	authors, err := models.Authors().Count(ctx, db)
+	if err != nil || authors != 10 {
+		log.Fatalf("Expected 10 authors, got %d (%v)", authors, err)
+	}
+
+	fmt.Println("Many-to-many links test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "links_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create many-to-many links test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "links_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run many-to-many links test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Many-to-many links test passed!") {
+		t.Errorf("Many-to-many links test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestFixtures(t *testing.T) {
+	// Fixture rows should be inserted with their references resolved,
+	// and random rows should only fill in the rest of the minimums
+	fixtures := map[string]string{
+		"fixtures/authors.yaml": `authors:
+  author_alice:
+    name: Alice
+    email: alice@example.com
+    bio: "@@alice"
+`,
+		"fixtures/categories.toml": `[categories.category_fiction]
+name = "Fiction"
+
+[categories.category_fantasy]
+name = "Fantasy"
+parent_id = "@category_fiction"
+`,
+		"fixtures/books.json": `{
+  "books": {
+    "book_hobbit": {
+      "title": "The Hobbit",
+      "isbn": "978-0261102217",
+      "author": "@author_alice",
+      "category_id": "@category_fantasy",
+      "pages": 310
+    }
+  }
+}
+`,
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.projectDir, "fixtures"), 0o755); err != nil {
+		t.Fatalf("Failed to create fixtures directory: %v", err)
+	}
+	for path, content := range fixtures {
+		if err := os.WriteFile(filepath.Join(s.projectDir, path), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to create fixture %s: %v", path, err)
+		}
+	}
+
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	fixtures, err := seeds.LoadFixtures("fixtures/authors.yaml", "fixtures/categories.toml", "fixtures/books.json")
+	if err != nil {
+		log.Fatal("Failed to load fixtures:", err)
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		Sequential:          true,
+		Fixtures:            fixtures,
+		MinAuthorsToSeed:    3,
+		MinCategoriesToSeed: 1,
+		MinBooksToSeed:      4,
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	alice, err := models.Authors(models.AuthorWhere.Email.EQ("alice@example.com")).One(ctx, db)
+	if err != nil {
+		log.Fatal("Fixture author was not inserted:", err)
+	}
+	if alice.Bio.String != "@alice" {
+		log.Fatalf("Expected the escaped bio @alice, got %q", alice.Bio.String)
+	}
+
+	fantasy, err := models.Categories(models.CategoryWhere.Name.EQ("Fantasy")).One(ctx, db)
+	if err != nil {
+		log.Fatal("Fixture category was not inserted:", err)
+	}
+	fiction, err := models.Categories(models.CategoryWhere.Name.EQ("Fiction")).One(ctx, db)
+	if err != nil || fantasy.ParentID != fiction.ID {
+		log.Fatalf("Expected Fantasy to be a child of Fiction, got %v (%v)", fantasy.ParentID, err)
+	}
+
+	hobbit, err := models.Books(models.BookWhere.Isbn.EQ("978-0261102217")).One(ctx, db)
+	if err != nil {
+		log.Fatal("Fixture book was not inserted:", err)
+	}
+	if hobbit.AuthorID != alice.ID.Int64 || hobbit.CategoryID != fantasy.ID.Int64 || hobbit.Pages.Int64 != 310 {
+		log.Fatalf("Fixture book has the wrong values: %+v", hobbit)
+	}
+
+	// random rows fill in the minimums around the fixtures
+	authors, _ := models.Authors().Count(ctx, db)
+	categories, _ := models.Categories().Count(ctx, db)
+	books, _ := models.Books().Count(ctx, db)
+	if authors != 3 || categories != 2 || books != 4 {
+		log.Fatalf("Expected 3 authors, 2 categories and 4 books, got %d, %d and %d", authors, categories, books)
+	}
+
+	// a reference through a composite foreign key sets every column of the key
+	for _, table := range []string{"milestones", "tasks", "projects", "tenants"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+	seeder = seeds.Seeder{
+		Sequential: true,
+		Fixtures: seeds.Fixtures{
+			"tenants":  {"tenant_acme": {"name": "Acme"}},
+			"projects": {"project_web": {"tenant": "@tenant_acme", "id": 7, "name": "Web"}},
+			"tasks":    {"task_launch": {"title": "Launch", "project": "@project_web"}},
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Tasks); err != nil {
+		log.Fatal("Seeder failed with composite fixtures:", err)
+	}
+
+	acme, err := models.Tenants(models.TenantWhere.Name.EQ("Acme")).One(ctx, db)
+	if err != nil {
+		log.Fatal("Fixture tenant was not inserted:", err)
+	}
+	launch, err := models.Tasks(models.TaskWhere.Title.EQ("Launch")).One(ctx, db)
+	if err != nil {
+		log.Fatal("Fixture task was not inserted:", err)
+	}
+	if launch.TenantID != acme.ID.Int64 || launch.ProjectID != 7 {
+		log.Fatalf("Expected the task to reference project (%d, 7), got (%d, %d)", acme.ID.Int64, launch.TenantID, launch.ProjectID)
+	}
+
+	seeder = seeds.Seeder{
+		Sequential: true,
+		Fixtures:   seeds.Fixtures{"books": {"book_orphan": {"title": "Orphan", "author_id": "@nobody"}}},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Books); err == nil {
+		log.Fatal("Expected an error for a reference to an unknown fixture")
+	}
+
+	fmt.Println("Fixtures test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "fixtures_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create fixtures test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "fixtures_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run fixtures test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Fixtures test passed!") {
+		t.Errorf("Fixtures test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestLoggerAndProgress(t *testing.T) {
+	// The seeder should be silent by default, log to a Logger when one is set
+	// and report the progress of every table until all of its rows are inserted
+	testProgram := `package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	var logs bytes.Buffer
+	var mu sync.Mutex
+	progress := map[string][2]int{}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		Logger:              slog.New(slog.NewTextHandler(&logs, nil)),
+		AuthorsBatchSize:    5,
+		MinAuthorsToSeed:    12,
+		MinCategoriesToSeed: 1,
+		MinBooksToSeed:      1,
+		OnProgress: func(table string, done, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			if last := progress[table]; done < last[0] {
+				log.Fatalf("Progress of %s went back from %d to %d", table, last[0], done)
+			}
+			progress[table] = [2]int{done, total}
+		},
+	}
+	if _, err := seeder.RunTables(ctx, db, models.TableNames.Authors); err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	if !strings.Contains(logs.String(), "msg=\"seeding table\" table=authors") ||
+		!strings.Contains(logs.String(), "msg=\"seeded table\" table=authors rows=12") {
+		log.Fatalf("Expected the authors table to be logged, got:\n%s", logs.String())
+	}
+	if last := progress["authors"]; last[0] != 12 || last[1] != 12 {
+		log.Fatalf("Expected the progress of authors to end at 12 of 12, got %v", last)
+	}
+
+	// without a Logger nothing is written to stdout
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		log.Fatal("Failed to create pipe:", err)
+	}
+	os.Stdout = w
+	seeder = seeds.Seeder{MinAuthorsToSeed: 2}
+	_, err = seeder.RunTables(ctx, db, models.TableNames.Authors)
+	w.Close()
+	os.Stdout = stdout
+	if err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	var printed bytes.Buffer
+	printed.ReadFrom(r)
+	if printed.Len() > 0 {
+		log.Fatalf("Expected no output without a Logger, got:\n%s", printed.String())
+	}
+
+	fmt.Println("Logger and progress test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "logger_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create logger test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "logger_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run logger test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Logger and progress test passed!") {
+		t.Errorf("Logger and progress test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestReport(t *testing.T) {
+	// Run should report the rows and links it inserted and the seed it used,
+	// in a form that can be encoded to JSON
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		Seed:                  7,
+		MinAuthorsToSeed:      5,
+		MinCategoriesToSeed:   4,
+		AuthorCategoriesCount: 6,
+		Fixtures:              seeds.Fixtures{"categories": {"category_poetry": {"name": "Poetry"}}},
+	}
+	report, err := seeder.RunInTx(ctx, db)
+	if err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	if report.Seed != 7 {
+		log.Fatalf("Expected the seed 7 in the report, got %d", report.Seed)
+	}
+	if report.Tables["books"] == nil || report.Tables["teams"] == nil {
+		log.Fatal("Expected every table in the report of a full run")
+	}
+
+	authors := report.Tables["authors"]
+	if authors.Rows != 5 || len(report.Rows.Authors) != 5 {
+		log.Fatalf("Expected 5 authors in the report, got %d and %d models", authors.Rows, len(report.Rows.Authors))
+	}
+	for _, author := range report.Rows.Authors {
+		if exists, err := models.AuthorExists(ctx, db, author.ID); err != nil || !exists {
+			log.Fatalf("Reported author %v is not in the database (%v)", author.ID, err)
+		}
+	}
+
+	categories := report.Tables["categories"]
+	if categories.Rows != 4 || categories.Fixtures != 1 || report.Rows.Categories[0].Name != "Poetry" {
+		log.Fatalf("Expected 4 categories with the fixture first, got %+v", categories)
+	}
+
+	var links int
+	err = db.QueryRow("SELECT COUNT(*) FROM author_categories").Scan(&links)
+	if err != nil || report.Tables["author_categories"].Links != 6 || links != 6 {
+		log.Fatalf("Expected 6 links, reported %d and counted %d (%v)", report.Tables["author_categories"].Links, links, err)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		log.Fatal("Failed to encode the report:", err)
+	}
+
+	var decoded struct {
+		Seed   int64
+		Tables map[string]struct{ Rows, Links int }
+		Rows   map[string][]map[string]interface{}
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		log.Fatal("Failed to decode the report:", err)
+	}
+	if decoded.Seed != 7 || decoded.Tables["authors"].Rows != 5 || len(decoded.Rows["authors"]) != 5 || decoded.Rows["authors"][0]["email"] == nil {
+		log.Fatalf("The encoded report does not match the report: %s", data)
+	}
+
+	if _, err := seeder.RunTables(ctx, db, "unknown"); err == nil {
+		log.Fatal("Expected an error for an unknown table")
+	}
+
+	fmt.Println("Report test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "report_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create report test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "report_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run report test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Report test passed!") {
+		t.Errorf("Report test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestRandomWithContext(t *testing.T) {
+	// RandomXWithContext should get the context, the row index and the random source
+	// of the table, and be used instead of RandomX
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+type prefixKey struct{}
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	run := func(ctx context.Context) []string {
+		for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+			if _, err := db.Exec("DELETE FROM " + table); err != nil {
+				log.Fatalf("Failed to clear %s: %v", table, err)
+			}
+		}
+
+		seeder := seeds.Seeder{
+			Sequential:       true,
+			Seed:             11,
+			MinAuthorsToSeed: 4,
+			RandomAuthor: func() (*models.Author, error) {
+				return nil, errors.New("RandomAuthor should not be used")
+			},
+			RandomAuthorWithContext: func(ctx context.Context, i int, r *rand.Rand) (*models.Author, error) {
+				prefix, _ := ctx.Value(prefixKey{}).(string)
+				return &models.Author{
+					Name:  fmt.Sprintf("%s-%04d", prefix, i+1),
+					Email: fmt.Sprintf("%s-%04d-%d@example.com", prefix, i+1, r.Intn(1000)),
+				}, nil
+			},
+		}
+		if _, err := seeder.RunTables(ctx, db, models.TableNames.Authors); err != nil {
+			log.Fatal("Seeder failed:", err)
+		}
+
+		authors, err := models.Authors(models.AuthorWhere.Name.LIKE(ctx.Value(prefixKey{}).(string)+"-%")).All(ctx, db)
+		if err != nil {
+			log.Fatal("Failed to get authors:", err)
+		}
+
+		var emails []string
+		for i, author := range authors {
+			if expected := fmt.Sprintf("user-%04d", i+1); author.Name != expected {
+				log.Fatalf("Expected author %s, got %s", expected, author.Name)
+			}
+			emails = append(emails, author.Email)
+		}
+		return emails
+	}
+
+	ctx := context.WithValue(context.Background(), prefixKey{}, "user")
+	first, second := run(ctx), run(ctx)
+	if len(first) != 4 {
+		log.Fatalf("Expected 4 authors, got %d", len(first))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			log.Fatalf("Expected the same emails with the same seed, got %v and %v", first, second)
+		}
+	}
+
+	fmt.Println("Random with context test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "random_context_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create random with context test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "random_context_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run random with context test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Random with context test passed!") {
+		t.Errorf("Random with context test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestInsertHooks(t *testing.T) {
+	// BeforeXInsert should be able to change or skip a row and abort seeding,
+	// and AfterXInsert should run for every inserted row
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	var after []int
+	seeder := seeds.Seeder{
+		Sequential:       true,
+		MinAuthorsToSeed: 6,
+		AuthorsBatchSize: 4,
+		BeforeAuthorInsert: func(ctx context.Context, i int, o *models.Author) error {
+			if i%3 == 2 {
+				return seeds.ErrSkipRow
+			}
+			o.Name = strings.ToUpper(o.Name)
+			return nil
+		},
+		AfterAuthorInsert: func(ctx context.Context, i int, o *models.Author) error {
+			if !o.ID.Valid {
+				return errors.New("the author has no ID after insert")
+			}
+			after = append(after, i)
+			return nil
+		},
+	}
+	report, err := seeder.RunTables(ctx, db, models.TableNames.Authors)
+	if err != nil {
+		log.Fatal("Seeder failed:", err)
+	}
+
+	authors, err := models.Authors().All(ctx, db)
+	if err != nil {
+		log.Fatal("Failed to get authors:", err)
+	}
+	if len(authors) != 4 || report.Tables["authors"].Rows != 4 {
+		log.Fatalf("Expected 2 of the 6 authors to be skipped, got %d", len(authors))
+	}
+	for _, author := range authors {
+		if author.Name != strings.ToUpper(author.Name) {
+			log.Fatalf("Expected BeforeAuthorInsert to change the name, got %s", author.Name)
+		}
+	}
+	if fmt.Sprint(after) != "[0 1 2 3]" {
+		log.Fatalf("Expected AfterAuthorInsert to run for every inserted author, got %v", after)
+	}
+
+	errStop := errors.New("stop")
+	seeder = seeds.Seeder{
+		Sequential:       true,
+		MinAuthorsToSeed: 3,
+		MinBooksToSeed:   3,
+		BeforeAuthorInsert: func(ctx context.Context, i int, o *models.Author) error {
+			return errStop
+		},
+	}
+	_, err = seeder.RunTables(ctx, db, models.TableNames.Books)
+	if !errors.Is(err, errStop) {
+		log.Fatalf("Expected the error of BeforeAuthorInsert, got %v", err)
+	}
+	if books, _ := models.Books().Count(ctx, db); books != 0 {
+		log.Fatalf("Expected no books once seeding is stopped, got %d", books)
+	}
+
+	// in concurrent mode, a hook error stops the tables seeded next to it
+	if _, err := db.Exec("DELETE FROM authors"); err != nil {
+		log.Fatal("Failed to clear authors:", err)
+	}
+	seeder = seeds.Seeder{
+		MinAuthorsToSeed:    50,
+		MinCategoriesToSeed: 3,
+		BeforeCategoryInsert: func(ctx context.Context, i int, o *models.Category) error {
+			return errStop
+		},
+		AfterAuthorInsert: func(ctx context.Context, i int, o *models.Author) error {
+			if i != 10 {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(10 * time.Second):
+				return errors.New("authors were not stopped by the error of BeforeCategoryInsert")
+			}
+		},
+	}
+	_, err = seeder.RunTables(ctx, db, models.TableNames.Authors, models.TableNames.Categories)
+	if !errors.Is(err, errStop) || errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "seeding authors") {
+		log.Fatalf("Expected only the error of BeforeCategoryInsert, got %v", err)
+	}
+	if authors, _ := models.Authors().Count(ctx, db); authors > 11 {
+		log.Fatalf("Expected authors to stop by the 11th, got %d", authors)
+	}
+
+	// the errors of tables that failed on their own are kept
+	errRandom := errors.New("no random author")
+	seeder = seeds.Seeder{
+		MinAuthorsToSeed:    5,
+		MinCategoriesToSeed: 3,
+		RandomAuthor: func() (*models.Author, error) {
+			return nil, errRandom
+		},
+		BeforeCategoryInsert: func(ctx context.Context, i int, o *models.Category) error {
+			return errStop
+		},
+	}
+	_, err = seeder.RunTables(ctx, db, models.TableNames.Authors, models.TableNames.Categories)
+	if !errors.Is(err, errStop) || !errors.Is(err, errRandom) {
+		log.Fatalf("Expected the errors of BeforeCategoryInsert and RandomAuthor, got %v", err)
+	}
+
+	fmt.Println("Insert hooks test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "hooks_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create insert hooks test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "hooks_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run insert hooks test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Insert hooks test passed!") {
+		t.Errorf("Insert hooks test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestUpsertTables(t *testing.T) {
+	// Tables listed in --upsert-tables should converge when the seeder runs again
+	// with the same seed, instead of getting the rows twice
+	if err := s.runCommand(s.binPath,
+		"-o", "upsert_seeds",
+		"-p", "upsertseeds",
+		"--wipe",
+		"--upsert-tables", "authors:email",
+		"sqlite3"); err != nil {
+		t.Fatalf("Failed to generate with upsert tables: %v", err)
+	}
+
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/models"
+	seeds "testproject/upsert_seeds"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			log.Fatalf("Failed to clear %s: %v", table, err)
+		}
+	}
+
+	ctx := context.Background()
+	seeder := seeds.Seeder{
+		Sequential:       true,
+		Seed:             3,
+		MinAuthorsToSeed: 5,
+		Fixtures:         seeds.Fixtures{"authors": {"author_alice": {"name": "Alice", "email": "alice@example.com"}}},
+	}
+
+	var ids [][]int64
+	for run := 0; run < 2; run++ {
+		report, err := seeder.RunTables(ctx, db, models.TableNames.Authors)
+		if err != nil {
+			log.Fatalf("Seeder failed on run %d: %v", run+1, err)
+		}
+
+		var runIDs []int64
+		for _, author := range report.Rows.Authors {
+			runIDs = append(runIDs, author.ID.Int64)
+		}
+		ids = append(ids, runIDs)
+	}
+
+	authors, err := models.Authors().Count(ctx, db)
+	if err != nil || authors != 5 {
+		log.Fatalf("Expected the second run to update the 5 authors, got %d (%v)", authors, err)
+	}
+	if fmt.Sprint(ids[0]) != fmt.Sprint(ids[1]) {
+		log.Fatalf("Expected both runs to return the same authors, got %v and %v", ids[0], ids[1])
+	}
+
+	fmt.Println("Upsert tables test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "upsert_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create upsert tables test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "upsert_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run upsert tables test: %v\nOutput: %s", err, output)
+	}
+
+	if !strings.Contains(output, "Upsert tables test passed!") {
+		t.Errorf("Upsert tables test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestCancelledRun(t *testing.T) {
+	// Cancelling the context while tables are seeded concurrently should stop the run
+	// without the waiting tables racing with the tables they wait for.
+	// The program runs with the race detector.
+	testProgram := `package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	_ "modernc.org/sqlite"
+	"testproject/seeds"
+	"testproject/models"
+)
+
+func main() {
+	db, err := sql.Open("sqlite", "test.db")
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	// Set single connection to avoid database lock issues
+	db.SetMaxOpenConns(1)
+
+	for run := 0; run < 5; run++ {
+		for _, table := range []string{"author_categories", "book_contributors", "reviews", "book_tags", "books", "authors", "categories"} {
+			if _, err := db.Exec("DELETE FROM " + table); err != nil {
+				log.Fatalf("Failed to clear %s: %v", table, err)
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		seeder := seeds.Seeder{
+			MinAuthorsToSeed:    100,
+			MinCategoriesToSeed: 5,
+			MinBooksToSeed:      20,
+			OnProgress: func(table string, done, total int) {
+				// cancel from another goroutine while authors are being inserted
+				if table == models.TableNames.Authors && done == total/2 {
+					go cancel()
+				}
+			},
+		}
+		_, err := seeder.RunTables(ctx, db, models.TableNames.Books, models.TableNames.Reviews)
+		cancel()
+		if !errors.Is(err, context.Canceled) {
+			log.Fatalf("Expected the run to be cancelled, got %v", err)
+		}
+	}
+
+	fmt.Println("Cancelled run test passed!")
+}
+`
+
+	testPath := filepath.Join(s.projectDir, "cancel_demo.go")
+	if err := os.WriteFile(testPath, []byte(testProgram), 0o644); err != nil {
+		t.Fatalf("Failed to create cancelled run test: %v", err)
+	}
+
+	output, err := s.runCommandWithOutput("go", "run", "-race", "cancel_demo.go")
+	if err != nil {
+		t.Fatalf("Failed to run cancelled run test: %v\nOutput: %s", err, output)
+	}
+
+	if strings.Contains(output, "DATA RACE") || !strings.Contains(output, "Cancelled run test passed!") {
+		t.Errorf("Cancelled run test failed. Output: %s", output)
+	}
+}
+
+func (s *IntegrationTestSuite) TestConfigurationOptions(t *testing.T) {
+	// Test different configuration options
+	customOutputDir := filepath.Join(s.projectDir, "custom_seeds")
+
+	// Test custom output directory and package name
+	if err := s.runCommand(s.binPath,
+		"-o", "custom_seeds",
+		"-p", "customseeds",
+		"sqlite3"); err != nil {
+		t.Fatalf("Failed to generate with custom config: %v", err)
+	}
+
+	// Verify custom output directory was created
+	if _, err := os.Stat(customOutputDir); os.IsNotExist(err) {
+		t.Error("Custom output directory was not created")
+	}
+
+	// Verify files were generated in custom directory
+	expectedFiles := []string{
+		"boilingseed_main.go",
+		"authors.go",
+		"books.go",
+		"categories.go",
+	}
+
+	for _, file := range expectedFiles {
+		path := filepath.Join(customOutputDir, file)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Errorf("Expected file %s was not generated in custom directory", file)
+		}
+	}
+
+	// Verify package name was changed
+	mainFile := filepath.Join(customOutputDir, "boilingseed_main.go")
+	content, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatalf("Failed to read main file: %v", err)
+	}
+
+	if !strings.Contains(string(content), "package customseeds") {
+		t.Error("Package name was not changed to customseeds")
+	}
+
+	// Test wipe option
+	// First create a dummy file in the directory
+	dummyFile := filepath.Join(customOutputDir, "dummy.txt")
+	if err := os.WriteFile(dummyFile, []byte("dummy"), 0o644); err != nil {
+		t.Fatalf("Failed to create dummy file: %v", err)
+	}
+
+	// Run with wipe option
+	if err := s.runCommand(s.binPath,
+		"-o", "custom_seeds",
+		"-p", "customseeds",
+		"--wipe",
+		"sqlite3"); err != nil {
+		t.Fatalf("Failed to generate with wipe option: %v", err)
+	}
+
+	// Verify dummy file was removed
+	if _, err := os.Stat(dummyFile); !os.IsNotExist(err) {
+		t.Error("Dummy file was not removed by wipe option")
+	}
+
+	// Verify seed files were still generated
+	if _, err := os.Stat(mainFile); os.IsNotExist(err) {
+		t.Error("Main file was not regenerated after wipe")
+	}
+
+	// Test no-hooks option
+	if err := s.runCommand(s.binPath,
+		"-o", "custom_seeds",
+		"-p", "customseeds",
+		"--wipe",
+		"--no-hooks",
+		"sqlite3"); err != nil {
+		t.Fatalf("Failed to generate with no-hooks option: %v", err)
+	}
+
+	content, err = os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatalf("Failed to read main file: %v", err)
+	}
+
+	if strings.Contains(string(content), "BeforeAuthorInsert") || strings.Contains(string(content), "ErrSkipRow") {
+		t.Error("Insert hooks were generated with the no-hooks option")
+	}
+
+	if err := s.runCommand("go", "build", "./custom_seeds"); err != nil {
+		t.Errorf("Seeder generated without hooks does not compile: %v", err)
+	}
+
+	// Test fixture-formats option, which replaces the formats of the config
+	if err := s.runCommand(s.binPath,
+		"-o", "custom_seeds",
+		"-p", "customseeds",
+		"--wipe",
+		"--fixture-formats", "json",
+		"sqlite3"); err != nil {
+		t.Fatalf("Failed to generate with fixture-formats option: %v", err)
+	}
+
+	content, err = os.ReadFile(filepath.Join(customOutputDir, "boilingseed_fixtures.go"))
+	if err != nil {
+		t.Fatalf("Failed to read fixtures file: %v", err)
+	}
+
+	if strings.Contains(string(content), "gopkg.in/yaml.v3") || strings.Contains(string(content), "go-toml") {
+		t.Error("YAML and TOML were imported without being listed in fixture-formats")
+	}
+
+	if err := s.runCommand("go", "build", "./custom_seeds"); err != nil {
+		t.Errorf("Seeder generated for JSON fixtures only does not compile: %v", err)
 	}
 }
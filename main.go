@@ -100,8 +100,9 @@ func main() {
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Debug mode prints stack traces on error")
 	rootCmd.PersistentFlags().BoolP("no-context", "", false, "Disable context.Context usage in the generated code")
 	rootCmd.PersistentFlags().BoolP("no-tests", "", false, "Disable generated go test files")
-	// Use hooks instead of // AfterXXXAdded
-	// rootCmd.PersistentFlags().BoolP("no-hooks", "", false, "Disable hooks feature for your models")
+	rootCmd.PersistentFlags().BoolP("no-hooks", "", false, "Disable the BeforeXXXInsert and AfterXXXInsert hooks of the seeder")
+	rootCmd.PersistentFlags().StringSlice("upsert-tables", nil, "Tables to seed with Upsert, as table or table:column to detect conflicts on a UNIQUE column")
+	rootCmd.PersistentFlags().StringSlice("fixture-formats", nil, "Formats LoadFixtures reads on top of JSON: yaml, toml")
 	rootCmd.PersistentFlags().BoolP("version", "", false, "Print the version")
 	rootCmd.PersistentFlags().BoolP("wipe", "", false, "Delete the output folder (rm -rf) before generation to ensure sanity")
 
@@ -178,12 +179,14 @@ func preRun(cmd *cobra.Command, args []string) error {
 		Debug:      viper.GetBool("debug"),
 		NoContext:  viper.GetBool("no-context"),
 		NoTests:    viper.GetBool("no-tests"),
+		NoHooks:    viper.GetBool("no-hooks"),
 		Wipe:       viper.GetBool("wipe"),
 		Version:    "boilingseed-" + boilingSeedVersion,
 
 		// Things we specifically override
-		TemplateDirs:      []string{tempTemplatesDir},
-		NoDriverTemplates: true,
+		TemplateDirs:        []string{tempTemplatesDir},
+		NoDriverTemplates:   true,
+		CustomTemplateFuncs: templateFunctions,
 	}
 
 	if cmdConfig.Debug {
@@ -205,10 +208,47 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cmdConfig.Imports = configureImports()
+	generators, err = parseGenerators(viper.Get("boilingseed.generators"))
+	if err != nil {
+		return fmt.Errorf("could not read generators: %w", err)
+	}
+
+	compositeFKeys, err := parseCompositeFKeys(viper.Get("boilingseed.foreign_keys"))
+	if err != nil {
+		return fmt.Errorf("could not read foreign keys: %w", err)
+	}
+
+	upserts := viper.GetStringSlice("upsert-tables")
+	if len(upserts) == 0 {
+		upserts = viper.GetStringSlice("boilingseed.upsert_tables")
+	}
+	upsertTables, err = parseUpsertTables(upserts)
+	if err != nil {
+		return fmt.Errorf("could not read upsert tables: %w", err)
+	}
+
+	formats := viper.GetStringSlice("fixture-formats")
+	if len(formats) == 0 {
+		formats = viper.GetStringSlice("boilingseed.fixture_formats")
+	}
+	fixtureFormats, err = parseFixtureFormats(formats)
+	if err != nil {
+		return fmt.Errorf("could not read fixture formats: %w", err)
+	}
+
+	cmdConfig.Imports = configureImports(cmdConfig.NoHooks, fixtureFormats)
 
 	cmdState, err = boilingcore.New(cmdConfig)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if err := addCompositeFKeys(cmdState.Tables, compositeFKeys); err != nil {
+		return err
+	}
+	addCompositeAliases(&cmdState.Config.Aliases, compositeFKeys)
+
+	return checkUpsertTables(cmdState.Tables, upsertTables)
 }
 
 func run(cmd *cobra.Command, args []string) error {
@@ -287,10 +327,14 @@ func copyTemplates(dir string) error {
 	})
 }
 
-func configureImports() importers.Collection {
+func configureImports(noHooks bool, formats map[string]bool) importers.Collection {
 	imports := importers.NewDefaultImports()
 
 	imports.All.Standard = []string{`"fmt"`, `"math"`}
+	if !noHooks {
+		// the BeforeXXXInsert hooks can skip a row with ErrSkipRow
+		imports.All.Standard = append(imports.All.Standard, `"errors"`)
+	}
 	imports.All.ThirdParty = []string{
 		fmt.Sprintf(`models "%s"`, modelsPkg),
 		`"github.com/aarondl/sqlboiler/v4/boil"`,
@@ -298,12 +342,33 @@ func configureImports() importers.Collection {
 		`"github.com/aarondl/randomize"`,
 	}
 	imports.Singleton["boilingseed_main"] = importers.Set{
-		Standard: []string{`"fmt"`, `"sync"`, `"time"`, `"context"`, `"math/rand"`},
+		Standard: []string{`"errors"`, `"fmt"`, `"sync"`, `"time"`, `"context"`, `"math"`, `"math/rand"`, `"hash/fnv"`, `"reflect"`, `"sort"`, `"strings"`, `"database/sql/driver"`},
 		ThirdParty: []string{
 			fmt.Sprintf(`models "%s"`, modelsPkg),
 			`"github.com/aarondl/sqlboiler/v4/boil"`,
+			`"github.com/aarondl/sqlboiler/v4/queries"`,
+			`"github.com/aarondl/strmangle"`,
+			`"github.com/aarondl/randomize"`,
 		},
 	}
+	imports.Singleton["boilingseed_fixtures"] = importers.Set{
+		Standard:   []string{`"bytes"`, `"context"`, `"encoding/json"`, `"errors"`, `"fmt"`, `"os"`, `"path/filepath"`, `"reflect"`, `"sort"`, `"strings"`},
+		ThirdParty: []string{`"github.com/aarondl/sqlboiler/v4/boil"`},
+	}
+	// the fixtures are only read from JSON unless other formats are asked for
+	if formats["toml"] {
+		fixtures := imports.Singleton["boilingseed_fixtures"]
+		fixtures.ThirdParty = append(fixtures.ThirdParty, `"github.com/pelletier/go-toml/v2"`)
+		imports.Singleton["boilingseed_fixtures"] = fixtures
+	}
+	if formats["yaml"] {
+		fixtures := imports.Singleton["boilingseed_fixtures"]
+		fixtures.ThirdParty = append(fixtures.ThirdParty, `"gopkg.in/yaml.v3"`)
+		imports.Singleton["boilingseed_fixtures"] = fixtures
+	}
+	imports.Singleton["boilingseed_faker"] = importers.Set{
+		Standard: []string{`"fmt"`, `"strings"`, `"time"`},
+	}
 
 	return imports
 }
@@ -1,28 +1,57 @@
 {{- if not .Table.IsView -}}
 {{ $alias := .Aliases.Table .Table.Name -}}
+{{ $parentFKeys := parentFKeys .Tables .Table -}}
+{{ $selfFKeys := selfFKeys .Table -}}
+{{ $deferredFKeys := deferredFKeys .Tables .Table -}}
+{{ $schemaTable := .Table.Name | .SchemaTable -}}
+{{ $association := isAssociation .Tables .Table -}}
+{{ $upsert := isUpsert .Table -}}
 
 var (
 	{{$alias.DownSingular}}ColumnsWithDefault    = []string{{"{"}}{{.Table.Columns | filterColumnsByDefault true | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
+	{{$alias.DownSingular}}ColumnsWithoutDefault = []string{{"{"}}{{.Table.Columns | filterColumnsByDefault false | filterColumnsByAuto false | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
 	{{$alias.DownSingular}}DBTypes = map[string]string{{"{"}}{{range $i, $col := .Table.Columns -}}{{- if ne $i 0}},{{end}}`{{$alias.Column $col.Name}}`: `{{$col.DBType}}`{{end}}{{"}"}}
 )
 
-{{if .Table.FKeys}}
-func default{{$alias.UpSingular}}ForeignKeySetter(i int, o *models.{{$alias.UpSingular}}{{- range $fkey := .Table.FKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, all{{$ftable.UpPlural}} models.{{$ftable.UpSingular}}Slice{{end}}) error {
-		{{range $fkey := .Table.FKeys -}}
+{{if $parentFKeys}}
+func default{{$alias.UpSingular}}ForeignKeySetter(i int, o *models.{{$alias.UpSingular}}{{- range $fkey := $parentFKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, all{{$ftable.UpPlural}} models.{{$ftable.UpSingular}}Slice{{end}}) error {
+		{{if $association -}}
+		// The parents in the primary key are the i-th combination of them.
+		// Each key is shifted by the one before it so that consecutive rows
+		// spread over every parent, and no two i below the number of combinations
+		// give the same one.
+		k, previous := i, 0
+		{{end -}}
+		{{range $fkey := $parentFKeys -}}
 			{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}
-			{{- $usesPrimitives := usesPrimitives $.Tables $fkey.Table $fkey.Column $fkey.ForeignTable $fkey.ForeignColumn -}}
 
 			if len(all{{$ftable.UpPlural}}) > 0 {
 				// set {{$ftable.DownSingular}}
+				{{if $fkey.Association -}}
+				{{$ftable.UpSingular}}Key := (k%len(all{{$ftable.UpPlural}}) + previous) % len(all{{$ftable.UpPlural}})
+				k, previous = k/len(all{{$ftable.UpPlural}}), {{$ftable.UpSingular}}Key
+				{{- else -}}
 				{{$ftable.UpSingular}}Key := int(math.Mod(float64(i), float64(len(all{{$ftable.UpPlural}}))))
+				{{- end}}
 				{{$ftable.DownSingular}} := all{{$ftable.UpPlural}}[{{$ftable.UpSingular}}Key]
 
+				{{if gt (len $fkey.Parts) 1 -}}
+				// every column of the composite key comes from the same {{$ftable.DownSingular}}
+				{{end -}}
+				{{range $part := $fkey.Parts -}}
+				{{- $usesPrimitives := usesPrimitives $.Tables $part.Table $part.Column $part.ForeignTable $part.ForeignColumn -}}
 				{{if $usesPrimitives -}}
-				o.{{$alias.Column $fkey.Column}} = {{$ftable.DownSingular}}.{{$ftable.Column $fkey.ForeignColumn}}
+				o.{{$alias.Column $part.Column}} = {{$ftable.DownSingular}}.{{$ftable.Column $part.ForeignColumn}}
 				{{else -}}
-				queries.Assign(&o.{{$alias.Column $fkey.Column}}, {{$ftable.DownSingular}}.{{$ftable.Column $fkey.ForeignColumn}})
+				queries.Assign(&o.{{$alias.Column $part.Column}}, {{$ftable.DownSingular}}.{{$ftable.Column $part.ForeignColumn}})
+				{{end -}}
 				{{end}}
-			}
+			}{{if $fkey.Nullable}} else {
+				// {{$fkey.ForeignTable}} is not seeded, so the random value would reference a missing row
+				{{range $part := $fkey.Parts}}{{if $part.Nullable -}}
+				queries.SetScanner(&o.{{$alias.Column $part.Column}}, nil)
+				{{end}}{{end -}}
+			}{{end}}
     {{end -}}
 
     return nil
@@ -30,92 +59,492 @@ func default{{$alias.UpSingular}}ForeignKeySetter(i int, o *models.{{$alias.UpSi
 {{end}}
 
 // defaultRandom{{$alias.UpSingular}} creates a random model.{{$alias.UpSingular}}
-// Used when Random{{$alias.UpSingular}} is not set in the Seeder
-func defaultRandom{{$alias.UpSingular}}() (*models.{{$alias.UpSingular}}, error){
+// Used when neither Random{{$alias.UpSingular}} nor Random{{$alias.UpSingular}}WithContext is set in the Seeder
+func defaultRandom{{$alias.UpSingular}}(seed *randomize.Seed) (*models.{{$alias.UpSingular}}, error){
 	o := &models.{{$alias.UpSingular}}{}
-	seed := randomize.NewSeed()
+	{{- with enumTypeColumns .Table}}
+	// the enum columns are set below
+	blacklist := append([]string{{"{"}}{{columnNames . | stringMap $.StringFuncs.quoteWrap | join ","}}{{"}"}}, {{$alias.DownSingular}}ColumnsWithDefault...)
+	err := randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, blacklist...)
+	{{- else}}
 	err := randomize.Struct(seed, o, {{$alias.DownSingular}}DBTypes, true, {{$alias.DownSingular}}ColumnsWithDefault...)
+	{{- end}}
+	if err != nil {
+		return o, err
+	}
+
+	{{range $col := .Table.Columns -}}
+	{{with columnGenerator $.Table $col -}}
+	o.{{$alias.Column $col.Name}} = {{.}}
+	{{end -}}
+	{{end}}
+	{{- range $col := uniqueColumns .Table -}}
+	{{if needsUniqueSuffix $.Table $col -}}
+	// {{$col.Name}} is UNIQUE, the number keeps the values from repeating
+	{{if eq $col.Type "string" -}}
+	o.{{$alias.Column $col.Name}} = fmt.Sprintf("%s-%d", o.{{$alias.Column $col.Name}}, seed.NextInt())
+	{{- else -}}
+	if o.{{$alias.Column $col.Name}}.Valid {
+		o.{{$alias.Column $col.Name}}.String = fmt.Sprintf("%s-%d", o.{{$alias.Column $col.Name}}.String, seed.NextInt())
+	}
+	{{- end}}
+	{{end -}}
+	{{end}}
+	return o, nil
+}
+
+// New{{$alias.UpSingular}} inserts a random {{$alias.UpSingular}} and returns it.
+// The mods are applied before the insert and can set any column,
+// including foreign keys to existing rows. Required parents
+// that are not set by the mods are created as well.
+func New{{$alias.UpSingular}}(ctx context.Context, exec boil.ContextExecutor, mods ...func(*models.{{$alias.UpSingular}})) (*models.{{$alias.UpSingular}}, error) {
+	o, err := defaultRandom{{$alias.UpSingular}}(factorySeed)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get Random {{$alias.UpSingular}}: %w", err)
+	}
+
+	{{if .Table.FKeys -}}
+	// Random foreign keys would not point to existing rows
+	{{range $fkey := .Table.FKeys -}}
+	o.{{$alias.Column $fkey.Column}} = *new({{($.Table.GetColumn $fkey.Column).Type}})
+	{{end}}
+	{{- end}}
+
+	for _, mod := range mods {
+		mod(o)
+	}
+
+	{{if $parentFKeys -}}
+	{{range $fkey := $parentFKeys -}}
+	{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+	var {{$ftable.DownPlural}} models.{{$ftable.UpSingular}}Slice
+	{{if not $fkey.Nullable -}}
+	if {{range $j, $part := $fkey.Parts}}{{if $j}} || {{end}}isZero(o.{{$alias.Column $part.Column}}){{end}} {
+		{{$ftable.DownSingular}}, err := New{{$ftable.UpSingular}}(ctx, exec)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create {{$ftable.UpSingular}} for {{$alias.UpSingular}}: %w", err)
+		}
+		{{$ftable.DownPlural}} = models.{{$ftable.UpSingular}}Slice{ {{- $ftable.DownSingular -}} }
+	}
+	{{end}}
+	{{end}}
+
+	if err := default{{$alias.UpSingular}}ForeignKeySetter(0, o{{- range $fkey := $parentFKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, {{$ftable.DownPlural}}{{end}}); err != nil {
+		return nil, fmt.Errorf("unable to get set foreign keys for {{$alias.UpSingular}}: %w", err)
+	}
+	{{- end}}
+
+	if err := o.Insert({{if not .NoContext}}ctx, {{end}}exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("unable to insert {{$alias.UpSingular}}: %w", err)
+	}
+
+	return o, nil
+}
+
+// insert{{$alias.UpSingular}}Fixture inserts a {{$alias.UpSingular}} with the values of a fixture row.
+// The columns that are not in the row are left to their zero value or default.
+{{- if $upsert}}
+// The row is upserted, so a row that is already there is updated.
+{{- end}}
+func insert{{$alias.UpSingular}}Fixture(ctx context.Context, exec boil.ContextExecutor, values map[string]interface{}) (*models.{{$alias.UpSingular}}, error) {
+	o := &models.{{$alias.UpSingular}}{}
+	if err := setFixtureColumns(o, values); err != nil {
+		return nil, err
+	}
 
-	return o, err
+	{{if $upsert -}}
+	if err := o.Upsert({{if not .NoContext}}ctx, {{end}}exec, {{upsertArgs .DriverName .Table}}); err != nil {
+		return nil, fmt.Errorf("unable to upsert {{$alias.UpSingular}}: %w", err)
+	}
+	{{- else -}}
+	if err := o.Insert({{if not .NoContext}}ctx, {{end}}exec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("unable to insert {{$alias.UpSingular}}: %w", err)
+	}
+	{{- end}}
+
+	return o, nil
+}
+
+// mix{{$alias.UpPlural}} returns the {{$alias.UpPlural}} that new rows are related to.
+// ratio of them are existing rows, spread evenly between the inserted ones.
+func mix{{$alias.UpPlural}}(inserted, existing models.{{$alias.UpSingular}}Slice, ratio float64) models.{{$alias.UpSingular}}Slice {
+	if ratio <= 0 || len(existing) == 0 {
+		return inserted
+	}
+	if ratio >= 1 || len(inserted) == 0 {
+		return existing
+	}
+
+	total := int(math.Round(float64(len(inserted)) / (1 - ratio)))
+	fromExisting := total - len(inserted)
+	if fromExisting == 0 {
+		return inserted
+	}
+
+	mixed := make(models.{{$alias.UpSingular}}Slice, 0, total)
+	e, n := 0, 0
+	for p := 0; p < total; p++ {
+		if (p+1)*fromExisting/total > e {
+			mixed = append(mixed, existing[e*len(existing)/fromExisting])
+			e++
+		} else {
+			mixed = append(mixed, inserted[n])
+			n++
+		}
+	}
+
+	return mixed
 }
 
-func (s Seeder) seed{{$alias.UpPlural}}(ctx context.Context, exec boil.ContextExecutor) error {
-	fmt.Println("Adding {{$alias.UpPlural}}")
+// seed{{$alias.UpPlural}} inserts the {{$alias.UpPlural}} and returns them.
+// The parents passed in are the rows inserted into the referenced tables during this run,
+// mixed with existing rows if ExistingParentsRatio is set.
+func (s Seeder) seed{{$alias.UpPlural}}(ctx context.Context, exec boil.ContextExecutor{{- range $parentFKeys -}}{{ $ftable := $.Aliases.Table .ForeignTable -}}, {{$ftable.DownPlural}} models.{{$ftable.UpSingular}}Slice{{end}}) (models.{{$alias.UpSingular}}Slice, error) {
+	s.log("seeding table", "table", "{{.Table.Name}}")
 	{{$alias.UpPlural}}ToAdd := s.Min{{$alias.UpPlural}}ToSeed
 
-  randomFunc := s.Random{{$alias.UpSingular}}
-  if randomFunc == nil {
-      randomFunc = defaultRandom{{$alias.UpSingular}}
+  r := s.randFor("{{.Table.Name}}")
+  var randomFunc func(i int) (*models.{{$alias.UpSingular}}, error)
+  switch {
+  case s.Random{{$alias.UpSingular}}WithContext != nil:
+      randomFunc = func(i int) (*models.{{$alias.UpSingular}}, error) {
+          return s.Random{{$alias.UpSingular}}WithContext(ctx, i, r)
+      }
+  case s.Random{{$alias.UpSingular}} != nil:
+      randomFunc = func(int) (*models.{{$alias.UpSingular}}, error) {
+          return s.Random{{$alias.UpSingular}}()
+      }
+  default:
+      seed := randomize.Seed(r.Int63())
+      randomFunc = func(int) (*models.{{$alias.UpSingular}}, error) {
+          return defaultRandom{{$alias.UpSingular}}(&seed)
+      }
   }
 
-  {{if .Table.FKeys}}
+  {{if $parentFKeys}}
   fkFunc := s.{{$alias.UpSingular}}ForeignKeySetter
   if fkFunc == nil {
       fkFunc = default{{$alias.UpSingular}}ForeignKeySetter
   }
   {{end}}
 
-	{{range .Table.FKeys -}}
-	{{ $ftable := $.Aliases.Table .ForeignTable -}}
-	{{$ftable.DownPlural}}, err := models.{{$ftable.UpPlural}}().All({{if not $.NoContext}}ctx, {{end}}exec)
-	if err != nil {
-		return fmt.Errorf("error getting {{$ftable.DownPlural}}: %w", err)
-	}
-	{{end}}
-	
 
 	{{range $tableIn := $.Tables -}}
 	{{ $aliasIn := $.Aliases.Table $tableIn.Name -}}
 
-	{{range $rel := $tableIn.ToManyRelationships -}}
-	{{if and (not $rel.ToJoinTable) (eq $.Table.Name $rel.ForeignTable) }}
+	{{range $rel := perRels $.Tables $tableIn -}}
+	{{if eq $.Table.Name $rel.ForeignTable }}
 
-	{{- $ftable := $.Aliases.Table $rel.ForeignTable -}}
 	{{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName -}}
-    
-	if s.{{$relAlias.Local}}Per{{$aliasIn.UpSingular}} * len({{$aliasIn.DownPlural}}) > {{$alias.UpPlural}}ToAdd {
-		{{$alias.UpPlural}}ToAdd = s.{{$relAlias.Local}}Per{{$aliasIn.UpSingular}} * len({{$aliasIn.DownPlural}})
+	{{- $setting := printf "%sPer%s" $relAlias.Local $aliasIn.UpSingular -}}
+
+	var {{$setting}}Counts []int
+	if s.{{$setting}}Max > 0 {
+		{{$setting}}Counts = childCounts(r, len({{$aliasIn.DownPlural}}), s.{{$setting}}Min, s.{{$setting}}Max, s.{{$setting}}Distribution)
+		if n := sumCounts({{$setting}}Counts); n > {{$alias.UpPlural}}ToAdd {
+			{{$alias.UpPlural}}ToAdd = n
+		}
+	} else if s.{{$setting}} * len({{$aliasIn.DownPlural}}) > {{$alias.UpPlural}}ToAdd {
+		{{$alias.UpPlural}}ToAdd = s.{{$setting}} * len({{$aliasIn.DownPlural}})
 	}
 
 	{{end}}{{/* if */}}
+	{{end -}}{{/* range tomany */}}
+	{{end -}}{{/* range tables */}}
+
+	{{range $tableIn := $.Tables -}}
+	{{ $aliasIn := $.Aliases.Table $tableIn.Name -}}
 
+	{{range $rel := perRels $.Tables $tableIn -}}
+	{{if eq $.Table.Name $rel.ForeignTable }}
+
+	{{- $relAlias := $.Aliases.ManyRelationship $rel.ForeignTable $rel.Name $rel.JoinTable $rel.JoinLocalFKeyName -}}
+	{{- $setting := printf "%sPer%s" $relAlias.Local $aliasIn.UpSingular -}}
+
+	if s.{{$setting}}Max > 0 && len({{$aliasIn.DownPlural}}) > 0 {
+		// Min{{$alias.UpPlural}}ToSeed and the other xxxPerXXX settings may need more {{$alias.DownPlural}} than were drawn.
+		// The default setter gives row i the parent at i, and would wrap around
+		// and give some parents more children than the maximum
+		topUpCounts({{$setting}}Counts, {{$alias.UpPlural}}ToAdd, s.{{$setting}}Max)
+		if sumCounts({{$setting}}Counts) < {{$alias.UpPlural}}ToAdd {
+			return nil, fmt.Errorf("cannot seed %d {{$alias.DownPlural}}: {{$setting}}Max allows at most %d", {{$alias.UpPlural}}ToAdd, len({{$aliasIn.DownPlural}})*s.{{$setting}}Max)
+		}
 
+		// every {{$aliasIn.UpSingular}} is repeated once per {{$alias.UpSingular}} it gets,
+		// so that the foreign key setter gives it that many
+		spread := make(models.{{$aliasIn.UpSingular}}Slice, 0, {{$alias.UpPlural}}ToAdd)
+		for p, count := range {{$setting}}Counts {
+			for c := 0; c < count; c++ {
+				spread = append(spread, {{$aliasIn.DownPlural}}[p])
+			}
+		}
+		{{$aliasIn.DownPlural}} = spread
+	}
+
+	{{end}}{{/* if */}}
 	{{end -}}{{/* range tomany */}}
 	{{end -}}{{/* range tables */}}
 
+	{{range $col := uniqueColumns .Table -}}
+	seen{{$alias.Column $col.Name}} := seenSet{}
+	{{end}}
+	{{- if $association}}
+	seenLinks := seenSet{}
+	{{end}}
 
-	for i := 0; i < {{$alias.UpPlural}}ToAdd; i++ {
+	build := func(i int{{if $selfFKeys}}, parent *models.{{$alias.UpSingular}}{{end}}) (*models.{{$alias.UpSingular}}, error) {
 		// create model
-		o, err := randomFunc()
+		o, err := randomFunc(i)
 		if err != nil {
-			return fmt.Errorf("unable to get Random {{$alias.UpSingular}}: %w", err)
+			return nil, fmt.Errorf("unable to get Random {{$alias.UpSingular}}: %w", err)
+		}
+
+		{{with uniqueColumns .Table -}}
+		// retry until the UNIQUE columns have values that were not used in this run
+		for retries := 0; ; retries++ {
+			duplicate := ""
+			{{range $col := . -}}
+			if duplicate == "" && seen{{$alias.Column $col.Name}}.has(o.{{$alias.Column $col.Name}}) {
+				duplicate = "{{$col.Name}}"
+			}
+			{{end}}
+			if duplicate == "" {
+				break
+			}
+
+			if retries >= s.Retries {
+				return nil, fmt.Errorf(
+					"unable to get a value that is not used yet for the UNIQUE constraint on {{$.Table.Name}}.%s after %d retries",
+					duplicate, retries,
+				)
+			}
+
+			if o, err = randomFunc(i); err != nil {
+				return nil, fmt.Errorf("unable to get Random {{$alias.UpSingular}}: %w", err)
+			}
 		}
 
-    {{if .Table.FKeys}}
+		{{range $col := . -}}
+		seen{{$alias.Column $col.Name}}.add(o.{{$alias.Column $col.Name}})
+		{{end}}
+		{{- end}}
+
+    {{if $parentFKeys}}
     // Set foreign keys
-    err = fkFunc(i, o{{- range $fkey := $.Table.FKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, {{$ftable.DownPlural}}{{end}})
+    err = fkFunc(i, o{{- range $fkey := $parentFKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, {{$ftable.DownPlural}}{{end}})
 		if err != nil {
-			return fmt.Errorf("unable to get set foreign keys for {{$alias.UpSingular}}: %w", err)
+			return nil, fmt.Errorf("unable to get set foreign keys for {{$alias.UpSingular}}: %w", err)
 		}
     {{end}}{{/* if */}}
 
-		// insert model
-		if err := o.Insert({{if not .NoContext}}ctx, {{end}}exec, boil.Infer()); err != nil {
-			return fmt.Errorf("unable to insert {{$alias.UpSingular}}: %w", err)
+		{{range $fkey := $parentFKeys -}}
+		{{if $fkey.Nullable -}}
+		{{- $relAlias := $alias.Relationship $fkey.Name -}}
+		if leaveNull(i, s.{{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio) {
+			{{range $part := $fkey.Parts}}{{if $part.Nullable -}}
+			queries.SetScanner(&o.{{$alias.Column $part.Column}}, nil)
+			{{end}}{{end -}}
+		}
+		{{end -}}
+		{{end}}
+
+		{{range $fkey := $selfFKeys -}}
+		// set the parent in the tree, roots have no parent
+		if parent == nil {
+			queries.SetScanner(&o.{{$alias.Column $fkey.Column}}, nil)
+		} else {
+			queries.Assign(&o.{{$alias.Column $fkey.Column}}, parent.{{$alias.Column $fkey.ForeignColumn}})
 		}
+		{{end}}
+
+		{{range $fkey := $deferredFKeys -}}
+		// set by backfill{{$alias.UpPlural}} once every table is seeded
+		{{range $part := $fkey.Parts}}{{if $part.Nullable -}}
+		queries.SetScanner(&o.{{$alias.Column $part.Column}}, nil)
+		{{end}}{{end -}}
+		{{end}}
+
+		{{if $association -}}
+		// every {{$alias.UpSingular}} links a different combination of parents,
+		// nil leaves out the rows past the last combination
+		link := []interface{}{ {{- range $fkey := $parentFKeys}}{{if $fkey.Association}}{{range $part := $fkey.Parts}}o.{{$alias.Column $part.Column}}, {{end}}{{end}}{{end -}} }
+		if seenLinks.has(link) {
+			return nil, nil
+		}
+		seenLinks.add(link)
+
+		{{end -}}
+		{{if not $.NoHooks -}}
+		if s.Before{{$alias.UpSingular}}Insert != nil {
+			err := s.Before{{$alias.UpSingular}}Insert(ctx, i, o)
+			if errors.Is(err, ErrSkipRow) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, &hookError{hook: "Before{{$alias.UpSingular}}Insert", err: err}
+			}
+		}
+
+		{{end -}}
+		return o, nil
+	}
+
+	{{if $selfFKeys -}}
+	// The roots are seeded first, every other level of the tree
+	// points to rows in the level before it
+	{{end -}}
+	rows := make(models.{{$alias.UpSingular}}Slice, 0, {{$alias.UpPlural}}ToAdd)
+	for i := 0; i < {{$alias.UpPlural}}ToAdd; i++ {
+		o, err := build(i{{if $selfFKeys}}, nil{{end}})
+		if err != nil {
+			return nil, &SeedError{Table: "{{.Table.Name}}", Row: i, Err: err}
+		}
+		{{if or $association (not $.NoHooks) -}}
+		if o == nil {
+			continue
+		}
+		{{end -}}
+		rows = append(rows, o)
 	}
 
+	total := len(rows)
+	{{- if $selfFKeys}}
+	for depth, width := 1, len(rows); depth < s.{{$alias.UpPlural}}TreeDepth; depth++ {
+		width *= s.{{$alias.UpPlural}}TreeBranching
+		total += width
+	}
+	{{- end}}
+
+	if err := s.insert{{$alias.UpPlural}}(ctx, exec, rows, 0, total); err != nil {
+		return nil, err
+	}
+	inserted := rows
+
+	{{if $selfFKeys -}}
+	level := rows
+	for depth := 1; depth < s.{{$alias.UpPlural}}TreeDepth; depth++ {
+		next := make(models.{{$alias.UpSingular}}Slice, 0, len(level)*s.{{$alias.UpPlural}}TreeBranching)
+		for _, parent := range level {
+			for j := 0; j < s.{{$alias.UpPlural}}TreeBranching; j++ {
+				i := len(inserted) + len(next)
+				o, err := build(i, parent)
+				if err != nil {
+					return nil, &SeedError{Table: "{{.Table.Name}}", Row: i, Err: err}
+				}
+				{{if or $association (not $.NoHooks) -}}
+				if o == nil {
+					continue
+				}
+				{{end -}}
+				next = append(next, o)
+			}
+		}
+
+		if err := s.insert{{$alias.UpPlural}}(ctx, exec, next, len(inserted), total); err != nil {
+			return nil, err
+		}
+		inserted = append(inserted, next...)
+		level = next
+	}
+	{{- end}}
+
     // run afterAdd
     if s.After{{$alias.UpPlural}}Added != nil {
       if err := s.After{{$alias.UpPlural}}Added(ctx); err != nil {
-          return fmt.Errorf("error running After{{$alias.UpPlural}}Added: %w", err)
+          return nil, fmt.Errorf("error running After{{$alias.UpPlural}}Added: %w", err)
       }
     }
 
-	fmt.Println("Finished adding {{$alias.UpPlural}}")
+	s.log("seeded table", "table", "{{.Table.Name}}", "rows", len(inserted))
+	return inserted, nil
+}
+
+{{if $upsert -}}
+// insert{{$alias.UpPlural}} upserts the rows one at a time, so a row with the same key
+// as a row that is already there updates it instead of being added again.
+{{- else -}}
+// insert{{$alias.UpPlural}} inserts the rows, up to {{$alias.UpPlural}}BatchSize with each statement.
+{{- end}}
+// offset is the index of the first row, used to report the row that failed
+// and the progress out of the total number of rows.
+func (s Seeder) insert{{$alias.UpPlural}}(ctx context.Context, exec boil.ContextExecutor, rows models.{{$alias.UpSingular}}Slice, offset, total int) error {
+	values := make([]interface{}, len(rows))
+	for i, o := range rows {
+		values[i] = o
+	}
+
+	insertOne := func(i int) error {
+		{{if $upsert -}}
+		if err := rows[i].Upsert({{if not .NoContext}}ctx, {{end}}exec, {{upsertArgs .DriverName .Table}}); err != nil {
+			return fmt.Errorf("unable to upsert {{$alias.UpSingular}}: %w", err)
+		}
+		{{- else -}}
+		if err := rows[i].Insert({{if not .NoContext}}ctx, {{end}}exec, boil.Infer()); err != nil {
+			return fmt.Errorf("unable to insert {{$alias.UpSingular}}: %w", err)
+		}
+		{{- end}}
+		return nil
+	}
+
+	var afterInsert func(i int) error
+	{{- if not .NoHooks}}
+	if s.After{{$alias.UpSingular}}Insert != nil {
+		afterInsert = func(i int) error {
+			if err := s.After{{$alias.UpSingular}}Insert(ctx, offset+i, rows[i]); err != nil {
+				return &hookError{hook: "After{{$alias.UpSingular}}Insert", err: err}
+			}
+			return nil
+		}
+	}
+	{{- end}}
+
+	i, err := insertRows(
+		ctx, exec, "{{$schemaTable}}", values, {{if $upsert}}1{{else}}s.{{$alias.UpPlural}}BatchSize{{end}},
+		{{$alias.DownSingular}}ColumnsWithDefault, {{$alias.DownSingular}}ColumnsWithoutDefault, insertOne, afterInsert,
+		func(done int) { s.progress("{{.Table.Name}}", offset+done, total) },
+	)
+	if err != nil {
+		return &SeedError{Table: "{{.Table.Name}}", Row: offset + i, Err: err}
+	}
+
 	return nil
 }
 
+{{if $deferredFKeys}}
+// backfill{{$alias.UpPlural}} sets the foreign keys that were left NULL on insert
+// to break a cycle between tables. It runs once every table has been seeded.
+func (s Seeder) backfill{{$alias.UpPlural}}(ctx context.Context, exec boil.ContextExecutor, rows models.{{$alias.UpSingular}}Slice{{- range $fkey := $deferredFKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, all{{$ftable.UpPlural}} models.{{$ftable.UpSingular}}Slice{{end}}) error {
+	// there is nothing to set if the referenced tables were not seeded
+	if {{range $i, $fkey := $deferredFKeys}}{{if $i}} && {{end}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable }}len(all{{$ftable.UpPlural}}) == 0{{end}} {
+		return nil
+	}
+
+	for i, o := range rows {
+		{{range $fkey := $deferredFKeys -}}
+		{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}
+		{{- $relAlias := $alias.Relationship $fkey.Name -}}
+		if len(all{{$ftable.UpPlural}}) > 0 && !leaveNull(i, s.{{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio) {
+			{{$ftable.DownSingular}} := all{{$ftable.UpPlural}}[i%len(all{{$ftable.UpPlural}})]
+			{{range $part := $fkey.Parts -}}
+			queries.Assign(&o.{{$alias.Column $part.Column}}, {{$ftable.DownSingular}}.{{$ftable.Column $part.ForeignColumn}})
+			{{end -}}
+		}
+		{{end}}
+
+		whitelist := boil.Whitelist(
+			{{- range $fkey := $deferredFKeys -}}{{range $part := $fkey.Parts -}}
+			models.{{$alias.UpSingular}}Columns.{{$alias.Column $part.Column}},
+			{{- end}}{{end -}}
+		)
+		if _, err := o.Update({{if not $.NoContext}}ctx, {{end}}exec, whitelist); err != nil {
+			return &SeedError{Table: "{{.Table.Name}}", Row: i, Err: fmt.Errorf("unable to backfill {{$alias.UpSingular}}: %w", err)}
+		}
+	}
+
+	return nil
+}
+{{end}}
+
 
 
 
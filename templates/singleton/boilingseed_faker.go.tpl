@@ -0,0 +1,118 @@
+// The random* functions generate realistic values for the columns
+// configured in the [boilingseed.generators] section, and for the columns
+// whose names follow common conventions. n comes from the seed,
+// so the values are deterministic.
+
+var (
+	firstNames = []string{
+		{{wordList "first_names"}}
+	}
+	lastNames = []string{
+		{{wordList "last_names"}}
+	}
+	loremWords = []string{
+		{{wordList "lorem"}}
+	}
+	streets = []string{
+		{{wordList "streets"}}
+	}
+	cities = []string{
+		{{wordList "cities"}}
+	}
+	companyWords = []string{
+		{{wordList "company_words"}}
+	}
+	companySuffixes = []string{
+		{{wordList "company_suffixes"}}
+	}
+)
+
+// mix scrambles n so that consecutive values of the seed look random
+func mix(n int64) uint64 {
+	z := uint64(n) + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func pick(words []string, n uint64) string {
+	return words[n%uint64(len(words))]
+}
+
+func randomFirstName(n int64) string {
+	return pick(firstNames, mix(n))
+}
+
+func randomLastName(n int64) string {
+	return pick(lastNames, mix(n))
+}
+
+func randomName(n int64) string {
+	m := mix(n)
+	return pick(firstNames, m) + " " + pick(lastNames, m>>16)
+}
+
+// randomEmail includes n, so the emails generated from a seed are unique
+func randomEmail(n int64) string {
+	m := mix(n)
+	return fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(pick(firstNames, m)), strings.ToLower(pick(lastNames, m>>16)), n)
+}
+
+func randomAddress(n int64) string {
+	m := mix(n)
+	return fmt.Sprintf("%d %s, %s", 1+m%9999, pick(streets, m>>16), pick(cities, m>>32))
+}
+
+func randomCity(n int64) string {
+	return pick(cities, mix(n))
+}
+
+func randomCompany(n int64) string {
+	m := mix(n)
+	return pick(companyWords, m) + " " + pick(companySuffixes, m>>16)
+}
+
+func randomURL(n int64) string {
+	m := mix(n)
+	return fmt.Sprintf("https://example.com/%s/%d", pick(loremWords, m), n)
+}
+
+func randomUUID(n int64) string {
+	hi, lo := mix(n), mix(^n)
+	hi = hi&^0xf000 | 0x4000         // version 4
+	lo = lo&^(0xc<<60) | (0x8 << 60) // RFC 4122 variant
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", hi>>32, (hi>>16)&0xffff, hi&0xffff, lo>>48, lo&0xffffffffffff)
+}
+
+func randomPhone(n int64) string {
+	m := mix(n)
+	return fmt.Sprintf("+1-555-%03d-%04d", m%1000, (m>>16)%10000)
+}
+
+func randomLorem(n int64) string {
+	m := mix(n)
+	words := make([]string, 4+m%8)
+	for i := range words {
+		m = mix(int64(m))
+		words[i] = pick(loremWords, m)
+	}
+
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// randomDate returns a date between from and to, given as unix timestamps
+func randomDate(n int64, from, to int64) time.Time {
+	days := (to-from)/(24*60*60) + 1
+	return time.Unix(from, 0).UTC().AddDate(0, 0, int(mix(n)%uint64(days)))
+}
+
+// randomInt returns a number between min and max, both included
+func randomInt(n int64, min, max int64) int64 {
+	return min + int64(mix(n)%uint64(max-min+1))
+}
+
+// randomFloat returns a number between min and max, both included
+func randomFloat(n int64, min, max float64) float64 {
+	return min + float64(mix(n)%1000001)/1000000*(max-min)
+}
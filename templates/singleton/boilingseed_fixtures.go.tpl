@@ -0,0 +1,261 @@
+// Fixtures are rows with fixed values, such as reference data, keyed by table and then by label.
+// The columns of a row are keyed by name. A foreign key can be set to "@label" to reference
+// another row of the fixtures, with the name of the column or the name without its _id suffix.
+// Every column of a composite foreign key is then set from the referenced row.
+// A string that starts with "@@" is a value starting with a single "@".
+type Fixtures map[string]map[string]map[string]interface{}
+
+// LoadFixtures reads fixture files and merges them.
+// The format of each file is picked from its extension: .json{{if fixtureFormat "yaml"}}, .yaml, .yml{{end}}{{if fixtureFormat "toml"}}, .toml{{end}}.
+{{- if not (and (fixtureFormat "yaml") (fixtureFormat "toml"))}}
+// YAML and TOML are read if they are listed in --fixture-formats when the seeder is generated.
+{{- end}}
+func LoadFixtures(paths ...string) (Fixtures, error) {
+	fixtures := Fixtures{}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read fixtures: %w", err)
+		}
+
+		var file Fixtures
+		switch ext := strings.ToLower(filepath.Ext(path)); ext {
+		case ".json":
+			// numbers are kept as they are written so large integers do not lose precision
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			err = decoder.Decode(&file)
+		{{if fixtureFormat "yaml" -}}
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(data, &file)
+		{{else -}}
+		case ".yaml", ".yml":
+			return nil, fmt.Errorf("unable to read fixtures from %s: the seeder was generated without --fixture-formats yaml", path)
+		{{end -}}
+		{{if fixtureFormat "toml" -}}
+		case ".toml":
+			err = toml.Unmarshal(data, &file)
+		{{else -}}
+		case ".toml":
+			return nil, fmt.Errorf("unable to read fixtures from %s: the seeder was generated without --fixture-formats toml", path)
+		{{end -}}
+		default:
+			return nil, fmt.Errorf("unable to read fixtures from %s: unknown format %q", path, ext)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read fixtures from %s: %w", path, err)
+		}
+
+		for table, rows := range file {
+			if fixtures[table] == nil {
+				fixtures[table] = map[string]map[string]interface{}{}
+			}
+			for label, row := range rows {
+				fixtures[table][label] = row
+			}
+		}
+	}
+
+	return fixtures, nil
+}
+
+// fixtureRow is a fixture row that is not inserted yet
+type fixtureRow struct {
+	table  string
+	label  string
+	values map[string]interface{}
+}
+
+// insertFixtures inserts the fixtures of the selected tables and adds them to rows.
+// Rows are inserted once the rows they reference are, so the order of the tables
+// does not matter as long as the references do not form a cycle.
+func (s Seeder) insertFixtures(ctx context.Context, exec boil.ContextExecutor, tables map[string]bool, rows *TableRows) error {
+	names := make([]string, 0, len(s.Fixtures))
+	for table := range s.Fixtures {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+
+	// labels are unique across tables, so references do not need to name the table
+	tableOf := map[string]string{}
+	var pending []fixtureRow
+	for _, table := range names {
+		if _, ok := requiredParents[table]; !ok {
+			return tableError(table, errors.New("unknown table in fixtures"))
+		}
+
+		labels := make([]string, 0, len(s.Fixtures[table]))
+		for label := range s.Fixtures[table] {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			if other, ok := tableOf[label]; ok {
+				return tableError(table, fmt.Errorf("fixture label %q is also used in %s", label, other))
+			}
+			tableOf[label] = table
+
+			if tables[table] {
+				pending = append(pending, fixtureRow{table: table, label: label, values: s.Fixtures[table][label]})
+			}
+		}
+	}
+
+	inserted := map[string]interface{}{}
+	for len(pending) > 0 {
+		var next []fixtureRow
+		for _, row := range pending {
+			values, ready, err := resolveFixture(row, tableOf, inserted)
+			if err != nil {
+				return &SeedError{Table: row.table, Row: -1, Err: fmt.Errorf("fixture %s: %w", row.label, err)}
+			}
+			if !ready {
+				next = append(next, row)
+				continue
+			}
+
+			o, err := insertFixture(ctx, exec, row.table, values, rows)
+			if err != nil {
+				return &SeedError{Table: row.table, Row: -1, Err: fmt.Errorf("fixture %s: %w", row.label, err)}
+			}
+			inserted[row.label] = o
+		}
+
+		if len(next) == len(pending) {
+			return &SeedError{
+				Table: next[0].table,
+				Row:   -1,
+				Err:   fmt.Errorf("fixture %s: its references form a cycle or point to a table that is not seeded", next[0].label),
+			}
+		}
+		pending = next
+	}
+
+	return nil
+}
+
+// resolveFixture replaces the references of the row with the values of the columns they point to.
+// A reference through a composite foreign key sets every column of the key from the referenced row.
+// It returns false if a referenced row is not inserted yet.
+func resolveFixture(row fixtureRow, tableOf map[string]string, inserted map[string]interface{}) (map[string]interface{}, bool, error) {
+	values := make(map[string]interface{}, len(row.values))
+	refs := map[string]string{}
+
+	for key, value := range row.values {
+		ref, ok := value.(string)
+		if !ok || !strings.HasPrefix(ref, "@") {
+			values[key] = value
+			continue
+		}
+		if strings.HasPrefix(ref, "@@") {
+			values[key] = ref[1:]
+			continue
+		}
+		refs[key] = ref
+	}
+
+	// references are set after the plain values, so the columns they share with them
+	// come from the referenced row, and in a fixed order so the result does not change between runs
+	keys := make([]string, 0, len(refs))
+	for key := range refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		ref := refs[key]
+
+		column := key
+		if _, ok := fixtureReferences[row.table][column]; !ok {
+			column = key + "_id"
+		}
+		reference, ok := fixtureReferences[row.table][column]
+		if !ok {
+			return nil, false, fmt.Errorf("%s is not a foreign key and cannot reference %s, use @@ to start a value with @", key, ref)
+		}
+
+		label := ref[1:]
+		if tableOf[label] != reference.table {
+			return nil, false, fmt.Errorf("%s references %s, which is not a fixture of %s", key, ref, reference.table)
+		}
+
+		o, ok := inserted[label]
+		if !ok {
+			return nil, false, nil
+		}
+
+		for local, foreign := range reference.columns {
+			v, err := fixtureColumn(o, foreign)
+			if err != nil {
+				return nil, false, err
+			}
+			values[local] = v
+		}
+	}
+
+	return values, true, nil
+}
+
+// fixtureReference is the table a foreign key references,
+// with the foreign column of each column of its constraint
+type fixtureReference struct {
+	table   string
+	columns map[string]string
+}
+
+// setFixtureColumns sets the columns of the model o points to.
+// Values go through JSON, so plain values can set null types, times and enums.
+func setFixtureColumns(o interface{}, values map[string]interface{}) error {
+	v := reflect.ValueOf(o).Elem()
+
+	for column, value := range values {
+		field, ok := fixtureField(v, column)
+		if !ok {
+			return fmt.Errorf("unknown column %s", column)
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", column, err)
+		}
+		if err := json.Unmarshal(data, field.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", column, err)
+		}
+	}
+
+	return nil
+}
+
+// fixtureColumn returns the value of a column of the model o points to
+func fixtureColumn(o interface{}, column string) (interface{}, error) {
+	field, ok := fixtureField(reflect.ValueOf(o).Elem(), column)
+	if !ok {
+		return nil, fmt.Errorf("unknown column %s", column)
+	}
+
+	return field.Interface(), nil
+}
+
+// fixtureField finds the field of a model struct by the column in its boil tag
+func fixtureField(v reflect.Value, column string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("boil"), ",")[0]
+		if tag == column {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// remaining is the number of random rows needed for a minimum
+// once the fixtures of the table are inserted
+func remaining(min, fixtures int) int {
+	if fixtures >= min {
+		return 0
+	}
+
+	return min - fixtures
+}
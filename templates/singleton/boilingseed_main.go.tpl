@@ -1,52 +1,1000 @@
 type Seeder struct {
     {{range $table := .Tables}}{{ $alias := $.Aliases.Table $table.Name -}}
     {{- if $table.IsJoinTable }}
+        {{- $fkey0 := index $table.FKeys 0}}
+        {{- $fkey1 := index $table.FKeys 1}}
+        {{- $alias0 := $.Aliases.Table $fkey0.ForeignTable}}
+        {{- $alias1 := $.Aliases.Table $fkey1.ForeignTable}}
+        {{- $relAlias0 := $alias.Relationship $fkey0.Name}}
+        {{- $relAlias1 := $alias.Relationship $fkey1.Name}}
         // The minimum number of {{titleCase $table.Name}} to seed
         MinRelsPer{{titleCase $table.Name}} int
+        // {{$relAlias0.Local}}Per{{$alias0.UpSingular}}Min and {{$relAlias0.Local}}Per{{$alias0.UpSingular}}Max bound the number of {{$alias1.UpPlural}} each {{$alias0.UpSingular}} is linked to,
+        // and {{$relAlias1.Local}}Per{{$alias1.UpSingular}}Min and {{$relAlias1.Local}}Per{{$alias1.UpSingular}}Max the number of {{$alias0.UpPlural}} each {{$alias1.UpSingular}} is linked to.
+        // A maximum of 0 means no maximum. They are used instead of MinRelsPer{{titleCase $table.Name}} when any of them is set
+        {{$relAlias0.Local}}Per{{$alias0.UpSingular}}Min int
+        {{$relAlias0.Local}}Per{{$alias0.UpSingular}}Max int
+        {{$relAlias1.Local}}Per{{$alias1.UpSingular}}Min int
+        {{$relAlias1.Local}}Per{{$alias1.UpSingular}}Max int
+        // {{titleCase $table.Name}}Count is the exact number of {{titleCase $table.Name}} to seed.
+        // It is used instead of the minimums and maximums when it is set
+        {{titleCase $table.Name}}Count int
+        // {{titleCase $table.Name}}Density is the share of every possible pair of {{$alias0.UpSingular}} and {{$alias1.UpSingular}}
+        // that is linked, between 0 and 1. It is used instead of the minimums and maximums when it is set
+        {{titleCase $table.Name}}Density float64
+        // {{titleCase $table.Name}}Skew gives a few rows on each side most of the links, following a power law.
+        // The larger it is, the more skewed the links are. If it is 0, every row is as likely to be linked
+        {{titleCase $table.Name}}Skew float64
     {{ else if not $table.IsView -}}
         // The minimum number of {{$alias.UpPlural}} to seed
+        {{- if isAssociation $.Tables $table}}
+        // Every {{$alias.UpSingular}} links a different combination of parents,
+        // so no more {{$alias.UpPlural}} are seeded than there are combinations
+        {{- end}}
         Min{{$alias.UpPlural}}ToSeed int
         // Random{{$alias.UpSingular}} creates a random models.{{$alias.UpSingular}}
         // It does not need to add relationships.
         // If one is not set, defaultRandom{{$alias.UpSingular}}() is used
         Random{{$alias.UpSingular}} func() (*models.{{$alias.UpSingular}}, error)
+        // Random{{$alias.UpSingular}}WithContext is used instead of Random{{$alias.UpSingular}} when it is set.
+        // It gets the context of the Run, the index of the row and the random source
+        // of the table, which keeps the values the same for the same Seed
+        Random{{$alias.UpSingular}}WithContext func(ctx context.Context, i int, r *rand.Rand) (*models.{{$alias.UpSingular}}, error)
         // After{{$alias.UpPlural}}Added runs after all {{$alias.UpPlural}} are added
         After{{$alias.UpPlural}}Added func(ctx context.Context) error
-        {{if $table.FKeys -}}
+        {{- if not $.NoHooks}}
+        // Before{{$alias.UpSingular}}Insert runs before each {{$alias.UpSingular}} is inserted, once its foreign keys are set,
+        // with the same index as Random{{$alias.UpSingular}}WithContext. It can change the row.
+        // Returning ErrSkipRow leaves the row out, any other error stops seeding {{$alias.UpPlural}}
+        Before{{$alias.UpSingular}}Insert func(ctx context.Context, i int, o *models.{{$alias.UpSingular}}) error
+        // After{{$alias.UpSingular}}Insert runs after each {{$alias.UpSingular}} is inserted, with the index
+        // of the row among the {{$alias.UpPlural}} inserted. An error stops seeding {{$alias.UpPlural}}
+        After{{$alias.UpSingular}}Insert func(ctx context.Context, i int, o *models.{{$alias.UpSingular}}) error
+        {{- end}}
+        {{- if isUpsert $table}}
+        // {{$alias.UpPlural}} are upserted one at a time, so {{$alias.UpPlural}}BatchSize is not used
+        {{- else}}
+        // {{$alias.UpPlural}}BatchSize is the maximum number of {{$alias.UpPlural}} inserted with a single statement.
+        // If it is 1 or less {{$alias.UpPlural}} are inserted one at a time
+        {{- end}}
+        {{$alias.UpPlural}}BatchSize int
+        {{if parentFKeys $.Tables $table -}}
         // default{{$alias.UpSingular}}ForeignKeySetter() is used if this is not set
         // setting this means that the xxxPerxxx settings cannot be guaranteed
-        {{$alias.UpSingular}}ForeignKeySetter func(i int, o *models.{{$alias.UpSingular}}{{- range $fkey := $table.FKeys -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, all{{$ftable.UpPlural}} models.{{$ftable.UpSingular}}Slice{{end}}) error
+        {{$alias.UpSingular}}ForeignKeySetter func(i int, o *models.{{$alias.UpSingular}}{{- range $fkey := parentFKeys $.Tables $table -}}{{ $ftable := $.Aliases.Table $fkey.ForeignTable -}}, all{{$ftable.UpPlural}} models.{{$ftable.UpSingular}}Slice{{end}}) error
+        {{end}}
+        {{- range $fkey := parentFKeys $.Tables $table}}{{if $fkey.Nullable}}
+        {{- $relAlias := $alias.Relationship $fkey.Name}}
+        // {{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio is the share of {{$alias.UpPlural}} whose {{$fkey.Column}} is left NULL, between 0 and 1.
+        // If it is 1, {{$alias.UpPlural}} are seeded without waiting for the {{$fkey.ForeignTable}} table
+        {{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio float64
+        {{end}}{{end}}
+        {{- range $fkey := deferredFKeys $.Tables $table}}
+        {{- $relAlias := $alias.Relationship $fkey.Name}}
+        // {{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio is the share of {{$alias.UpPlural}} whose {{$fkey.Column}} is left NULL by the backfill, between 0 and 1
+        {{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio float64
+        {{end}}
+        {{- if selfFKeys $table}}
+        // {{$alias.UpPlural}} reference themselves, so they are seeded as a tree.
+        // Min{{$alias.UpPlural}}ToSeed is the number of roots, and each level
+        // after that gets {{$alias.UpPlural}}TreeBranching children per row of the level before it.
+        // A depth of 1 or less seeds only the roots.
+        {{$alias.UpPlural}}TreeDepth int
+        {{$alias.UpPlural}}TreeBranching int
         {{end}}
     {{- end}}
 
     {{end}}{{/* range tables */}}
 
     {{range $table := .Tables -}}{{ $alias := $.Aliases.Table $table.Name -}}
-    {{range $table.ToManyRelationships -}}{{if not .ToJoinTable -}}
+    {{range perRels $.Tables $table -}}
         {{- $ftable := $.Aliases.Table .ForeignTable -}}
         {{- $relAlias := $.Aliases.ManyRelationship .ForeignTable .Name .JoinTable .JoinLocalFKeyName -}}
+        {{- $ftable := $.Aliases.Table .ForeignTable}}
         {{$relAlias.Local}}Per{{$alias.UpSingular}} int
-    {{end -}}{{/* if jointable */}}
-    {{- end -}}{{/* range tomany */}}
+        // {{$relAlias.Local}}Per{{$alias.UpSingular}}Min and {{$relAlias.Local}}Per{{$alias.UpSingular}}Max give every {{$alias.UpSingular}}
+        // a number of {{$ftable.UpPlural}} in that range, drawn from {{$relAlias.Local}}Per{{$alias.UpSingular}}Distribution.
+        // They are used instead of {{$relAlias.Local}}Per{{$alias.UpSingular}} when the maximum is set
+        {{$relAlias.Local}}Per{{$alias.UpSingular}}Min int
+        {{$relAlias.Local}}Per{{$alias.UpSingular}}Max int
+        {{$relAlias.Local}}Per{{$alias.UpSingular}}Distribution Distribution
+    {{end -}}{{/* range tomany */}}
     {{- end -}}{{/* range tables */}}
 
-    // Number of times to retry getting a unique relationship in many-to-many relationships
+    // Number of times to retry getting a value that is not used yet for a UNIQUE column.
+    // Only single-column UNIQUE constraints and primary keys are checked: the drivers
+    // do not report constraints over several columns, so those can still fail on insert
     Retries int
+
+    // Sequential seeds the tables one after the other on a single goroutine
+    // instead of concurrently. Use this when the executor cannot run
+    // statements concurrently, for example a *sql.Tx or SQLite.
+    Sequential bool
+
+    // ExistingParentsRatio is the share of relationships that point to rows
+    // that were already in the database before the Run, between 0 and 1.
+    // If it is 0, relationships only point to rows inserted during the Run.
+    // Existing rows of the referenced tables are loaded when it is set.
+    ExistingParentsRatio float64
+
+    // Fixtures are inserted before the random rows, see LoadFixtures.
+    // The random rows can reference them, and only the rows needed
+    // on top of the fixtures are added to reach MinXXXToSeed
+    Fixtures Fixtures
+
+    // Logger gets a message when each table starts and finishes seeding.
+    // If it is nil, nothing is logged
+    Logger Logger
+
+    // OnProgress is called after rows are inserted, with the number of rows of the table
+    // inserted so far and the number of rows to insert. Tables that are seeded
+    // concurrently call it from their own goroutines
+    OnProgress func(table string, done, total int)
+
+    // Seed drives every random choice made by the seeder.
+    // Running with the same seed against an empty database inserts the same rows.
+    // If it is 0, a seed based on the current time is used
+    Seed int64
+
+    // stopAtFirstError is set by RunInTx, which rolls back on any error,
+    // so there is no point in seeding the other tables
+    stopAtFirstError bool
+}
+
+// Distribution is how the number of children is spread over the parents
+// when a range is set with the xxxPerXXXMin and xxxPerXXXMax fields
+type Distribution int
+
+const (
+	// DistributionUniform gives every number in the range the same chance
+	DistributionUniform Distribution = iota
+	// DistributionNormal gives most parents a number close to the middle of the range
+	DistributionNormal
+	// DistributionZipf follows a power law: most parents get the minimum
+	// and a few of them get many more children
+	DistributionZipf
+)
+
+// childCounts draws the number of children of each of n parents
+// between min and max from the distribution
+func childCounts(r *rand.Rand, n, min, max int, dist Distribution) []int {
+	if min < 0 {
+		min = 0
+	}
+	if max < min {
+		max = min
+	}
+
+	var zipf *rand.Zipf
+	if dist == DistributionZipf && max > min {
+		zipf = rand.NewZipf(r, 1.5, 1, uint64(max-min))
+	}
+
+	counts := make([]int, n)
+	for p := range counts {
+		switch {
+		case max == min:
+			counts[p] = min
+		case dist == DistributionNormal:
+			// the range covers 3 standard deviations on each side of the middle
+			mean, stddev := float64(min+max)/2, float64(max-min)/6
+			count := int(math.Round(r.NormFloat64()*stddev + mean))
+			counts[p] = int(math.Max(float64(min), math.Min(float64(max), float64(count))))
+		case dist == DistributionZipf:
+			counts[p] = min + int(zipf.Uint64())
+		default:
+			counts[p] = min + r.Intn(max-min+1)
+		}
+	}
+
+	return counts
+}
+
+// sumCounts returns the number of children drawn by childCounts
+func sumCounts(counts []int) int {
+	sum := 0
+	for _, count := range counts {
+		sum += count
+	}
+
+	return sum
+}
+
+// topUpCounts adds children to the parents that have fewer than max,
+// one per parent in turn, until the counts add up to at least total
+// or every parent has max children
+func topUpCounts(counts []int, total, max int) {
+	sum := sumCounts(counts)
+	for sum < total {
+		added := false
+		for p := range counts {
+			if sum < total && counts[p] < max {
+				counts[p]++
+				sum++
+				added = true
+			}
+		}
+
+		if !added {
+			return
+		}
+	}
+}
+
+// linkPlan is how many rows of a join table are added, see planLinks
+type linkPlan struct {
+	minRels    int
+	min0, max0 int
+	min1, max1 int
+	count      int
+	density    float64
+	skew       float64
+}
+
+// planLinks picks the rows of the second side each of the n0 rows of the first side
+// is linked to. No pair is picked twice.
+//
+// An exact count, or a density of all n0*n1 pairs, is spread over the rows.
+// Otherwise every row of the first side gets between min0 and max0 links,
+// and the rows of the second side that are still below min1 get more,
+// as far as max0 allows. minRels is the minimum on both sides when
+// no other option is set, and every row gets a link if it is 0 as well.
+func planLinks(r *rand.Rand, n0, n1 int, plan linkPlan) [][]int {
+	links := make([][]int, n0)
+	if n0 == 0 || n1 == 0 {
+		return links
+	}
+
+	weights0, weights1 := skewWeights(r, n0, plan.skew), skewWeights(r, n1, plan.skew)
+	all1 := make([]int, n1)
+	for j := range all1 {
+		all1[j] = j
+	}
+
+	count := plan.count
+	if count <= 0 && plan.density > 0 {
+		count = int(math.Round(plan.density * float64(n0*n1)))
+	}
+
+	if count > 0 {
+		if count > n0*n1 {
+			count = n0 * n1
+		}
+
+		cumulative := make([]float64, n0)
+		total := 0.0
+		for i, weight := range weights0 {
+			total += weight
+			cumulative[i] = total
+		}
+
+		degrees := make([]int, n0)
+		for c := 0; c < count; c++ {
+			i := sort.SearchFloat64s(cumulative, r.Float64()*total)
+			if i == n0 {
+				i = n0 - 1
+			}
+			// the row is linked to every row of the other side already
+			for degrees[i] == n1 {
+				i = (i + 1) % n0
+			}
+			degrees[i]++
+		}
+
+		for i := range links {
+			links[i] = sample(r, all1, weights1, degrees[i])
+		}
+
+		return links
+	}
+
+	if plan.min0 == 0 && plan.max0 == 0 && plan.min1 == 0 && plan.max1 == 0 {
+		plan.min0, plan.min1 = plan.minRels, plan.minRels
+		if plan.minRels <= 0 {
+			plan.min0, plan.min1 = 1, 1
+		}
+	}
+
+	degrees1 := make([]int, n1)
+	for i := range links {
+		k := plan.min0
+		if plan.max0 > plan.min0 {
+			k += r.Intn(plan.max0 - plan.min0 + 1)
+		}
+
+		candidates := all1
+		if plan.max1 > 0 {
+			candidates = nil
+			for j, degree := range degrees1 {
+				if degree < plan.max1 {
+					candidates = append(candidates, j)
+				}
+			}
+		}
+
+		links[i] = sample(r, candidates, weights1, k)
+		for _, j := range links[i] {
+			degrees1[j]++
+		}
+	}
+
+	for j, degree := range degrees1 {
+		if degree >= plan.min1 {
+			continue
+		}
+
+		var candidates []int
+		for i, linked := range links {
+			if plan.max0 > 0 && len(linked) >= plan.max0 {
+				continue
+			}
+
+			isLinked := false
+			for _, l := range linked {
+				isLinked = isLinked || l == j
+			}
+			if !isLinked {
+				candidates = append(candidates, i)
+			}
+		}
+
+		for _, i := range sample(r, candidates, weights0, plan.min1-degree) {
+			links[i] = append(links[i], j)
+		}
+	}
+
+	return links
+}
+
+// skewWeights returns the weight of each of n rows.
+// The rows are ranked in a random order, and the weight of a row
+// falls with its rank following a power law with the skew as exponent.
+func skewWeights(r *rand.Rand, n int, skew float64) []float64 {
+	weights := make([]float64, n)
+	if skew <= 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		return weights
+	}
+
+	for i, rank := range r.Perm(n) {
+		weights[i] = 1 / math.Pow(float64(rank+1), skew)
+	}
+
+	return weights
+}
+
+// sample picks k of the candidates without replacement.
+// Candidates with a larger weight are more likely to be picked.
+func sample(r *rand.Rand, candidates []int, weights []float64, k int) []int {
+	if k <= 0 {
+		return nil
+	}
+	if k >= len(candidates) {
+		return append([]int(nil), candidates...)
+	}
+
+	// every candidate gets a random key that tends to be larger with its weight,
+	// the candidates with the largest keys are picked
+	type keyed struct {
+		candidate int
+		key       float64
+	}
+	keys := make([]keyed, len(candidates))
+	for c, candidate := range candidates {
+		keys[c] = keyed{candidate, math.Pow(r.Float64(), 1/weights[candidate])}
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a].key > keys[b].key })
+
+	picked := make([]int, k)
+	for c := range picked {
+		picked[c] = keys[c].candidate
+	}
+
+	return picked
+}
+
+// Logger gets the messages of the seeder, as a message followed by key-value pairs.
+// *slog.Logger can be used as a Logger.
+type Logger interface {
+	Info(msg string, args ...interface{})
+}
+
+// log sends a message to the Logger, if there is one
+func (s Seeder) log(msg string, args ...interface{}) {
+	if s.Logger != nil {
+		s.Logger.Info(msg, args...)
+	}
+}
+
+// progress reports the rows of a table inserted so far to OnProgress, if it is set
+func (s Seeder) progress(table string, done, total int) {
+	if s.OnProgress != nil {
+		s.OnProgress(table, done, total)
+	}
+}
+
+{{if not .NoHooks -}}
+// ErrSkipRow is returned by a BeforeXXXInsert hook to leave the row out
+var ErrSkipRow = errors.New("skip row")
+
+// hookError is the error returned by a BeforeXXXInsert or AfterXXXInsert hook.
+// It stops the whole run, not only the table of the hook.
+type hookError struct {
+	hook string
+	err  error
+}
+
+func (e *hookError) Error() string {
+	return fmt.Sprintf("error running %s: %v", e.hook, e.err)
+}
+
+func (e *hookError) Unwrap() error {
+	return e.err
+}
+
+{{end -}}
+// SeedError is returned when seeding a table fails
+type SeedError struct {
+	// Table is the name of the table that failed to seed
+	Table string
+	// Row is the index of the row that failed,
+	// or -1 if the failure is not tied to a single row
+	Row int
+	// Err is the cause of the failure
+	Err error
+}
+
+func (e *SeedError) Error() string {
+	if e.Row < 0 {
+		return fmt.Sprintf("seeding %s: %v", e.Table, e.Err)
+	}
+
+	return fmt.Sprintf("seeding %s row %d: %v", e.Table, e.Row, e.Err)
+}
+
+func (e *SeedError) Unwrap() error {
+	return e.Err
+}
+
+// tableError wraps err in a SeedError for the table
+// unless it already contains one
+func tableError(table string, err error) error {
+	var seedErr *SeedError
+	if errors.As(err, &seedErr) {
+		return err
+	}
+
+	return &SeedError{Table: table, Row: -1, Err: err}
+}
+
+// factorySeed is used by the New functions to create random models
+var factorySeed = randomize.NewSeed()
+
+// isZero reports whether v is the zero value of its type,
+// e.g. a foreign key that has not been set
+func isZero(v interface{}) bool {
+	return reflect.ValueOf(v).IsZero()
+}
+
+// seenSet tracks the values used for a UNIQUE column or key during a run
+type seenSet map[string]struct{}
+
+// key returns the key a value is tracked by,
+// and false for NULL which never conflicts with other values.
+// A []interface{} is a combination of values, such as the columns of a composite key.
+func (s seenSet) key(v interface{}) (string, bool) {
+	if values, ok := v.([]interface{}); ok {
+		keys := make([]string, len(values))
+		for i, value := range values {
+			key, ok := s.key(value)
+			if !ok {
+				return "", false
+			}
+			keys[i] = key
+		}
+
+		return strings.Join(keys, ","), true
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil || value == nil {
+			return "", false
+		}
+		v = value
+	}
+
+	return fmt.Sprintf("%T:%v", v, v), true
+}
+
+func (s seenSet) has(v interface{}) bool {
+	key, ok := s.key(v)
+	if !ok {
+		return false
+	}
+
+	_, seen := s[key]
+	return seen
 }
 
+func (s seenSet) add(v interface{}) {
+	if key, ok := s.key(v); ok {
+		s[key] = struct{}{}
+	}
+}
+
+// randFor returns the source of randomness for a table.
+// Every table gets its own source derived from the seed, so tables
+// that are seeded concurrently do not change each other's values.
+func (s Seeder) randFor(table string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(table))
+
+	return rand.New(rand.NewSource(s.Seed ^ int64(h.Sum64())))
+}
+
+// insertRows inserts the rows of a table, up to batchSize rows with each statement.
+// Consecutive rows are inserted together if they set the same columns that have defaults,
+// and the columns left to the database are read back into the rows.
+// Rows that cannot be batched are inserted one at a time with insertOne.
+// afterInsert, if it is not nil, runs for every row once the statement that inserted it has,
+// and progress gets the number of rows inserted after each statement.
+// It returns the index of the row that failed.
+func insertRows(ctx context.Context, exec boil.ContextExecutor, table string, rows []interface{}, batchSize int, withDefault, withoutDefault []string, insertOne, afterInsert func(i int) error, progress func(done int)) (int, error) {
+	for start := 0; start < len(rows); {
+		nzDefaults := queries.NonZeroDefaultSet(withDefault, rows[start])
+
+		end := start + 1
+		for end < len(rows) && end-start < batchSize &&
+			strmangle.StringSliceMatch(nzDefaults, queries.NonZeroDefaultSet(withDefault, rows[end])) {
+			end++
+		}
+
+		columns := append(append([]string{}, withoutDefault...), nzDefaults...)
+		returning := strmangle.SetComplement(withDefault, nzDefaults)
+
+		{{if .Dialect.UseLastInsertID -}}
+		// The driver cannot return the generated columns of a multi-row insert
+		canBatch := len(columns) > 0 && len(returning) == 0
+		{{- else if .Dialect.UseOutputClause -}}
+		// OUTPUT does not return the rows in the order they were inserted,
+		// so the generated columns could not be matched to their rows
+		canBatch := len(columns) > 0 && len(returning) == 0
+		{{- else -}}
+		canBatch := len(columns) > 0
+		{{- end}}
+
+		if end-start == 1 || !canBatch {
+			for i := start; i < end; i++ {
+				if err := insertOne(i); err != nil {
+					return i, err
+				}
+				if afterInsert == nil {
+					continue
+				}
+				if err := afterInsert(i); err != nil {
+					return i, err
+				}
+			}
+		} else {
+			if err := insertBatch(ctx, exec, table, rows[start:end], columns, returning); err != nil {
+				return start, err
+			}
+			for i := start; i < end && afterInsert != nil; i++ {
+				if err := afterInsert(i); err != nil {
+					return i, err
+				}
+			}
+		}
+
+		progress(end)
+		start = end
+	}
+
+	return 0, nil
+}
+
+// insertBatch inserts the rows with a single multi-row statement
+// and reads the returning columns back into the rows
+func insertBatch(ctx context.Context, exec boil.ContextExecutor, table string, rows []interface{}, columns, returning []string) error {
+	mapping := queries.MakeStructMapping(reflect.TypeOf(rows[0]))
+	valueMapping, err := queries.BindMapping(reflect.TypeOf(rows[0]), mapping, columns)
+	if err != nil {
+		return err
+	}
+	retMapping, err := queries.BindMapping(reflect.TypeOf(rows[0]), mapping, returning)
+	if err != nil {
+		return err
+	}
+
+	vals := make([]interface{}, 0, len(rows)*len(columns))
+	for _, row := range rows {
+		vals = append(vals, queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(row)), valueMapping)...)
+	}
 
-func (s Seeder) Run(ctx context.Context, exec boil.ContextExecutor) error {
-	rand.Seed(time.Now().Unix())
+	var output, returningClause string
+	if len(returning) > 0 {
+		{{if .Dialect.UseOutputClause -}}
+		output = fmt.Sprintf("OUTPUT INSERTED.{{.LQ}}%s{{.RQ}} ", strings.Join(returning, "{{.RQ}},INSERTED.{{.LQ}}"))
+		{{- else -}}
+		returningClause = fmt.Sprintf(" RETURNING {{.LQ}}%s{{.RQ}}", strings.Join(returning, "{{.RQ}},{{.LQ}}"))
+		{{- end}}
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s ({{.LQ}}%s{{.RQ}}) %sVALUES %s%s",
+		table, strings.Join(columns, "{{.RQ}},{{.LQ}}"), output,
+		batchPlaceholders(len(rows), len(columns)), returningClause,
+	)
+
+	if len(returning) == 0 {
+		_, err := exec.ExecContext(ctx, query, vals...)
+		return err
+	}
+
+	results, err := exec.QueryContext(ctx, query, vals...)
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+
+	// RETURNING gives the rows back in the order of the VALUES.
+	// OUTPUT does not, so insertRows never gets here with returning columns on MSSQL
+	for _, row := range rows {
+		if !results.Next() {
+			return fmt.Errorf("%d rows inserted but fewer returned", len(rows))
+		}
+
+		if err := results.Scan(queries.PtrsFromMapping(reflect.Indirect(reflect.ValueOf(row)), retMapping)...); err != nil {
+			return fmt.Errorf("unable to read the returned columns: %w", err)
+		}
+	}
+
+	return results.Err()
+}
+
+// batchPlaceholders returns the placeholders for the VALUES
+// of a multi-row insert, e.g. ($1,$2),($3,$4)
+func batchPlaceholders(rows, columns int) string {
+	var b strings.Builder
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteByte('(')
+		for j := 0; j < columns; j++ {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			{{if .Dialect.UseIndexPlaceholders -}}
+			fmt.Fprintf(&b, "$%d", i*columns+j+1)
+			{{- else -}}
+			b.WriteByte('?')
+			{{- end}}
+		}
+		b.WriteByte(')')
+	}
+
+	return b.String()
+}
+
+// TableRows holds rows of each table, such as the rows inserted during a single Run
+type TableRows struct {
+    {{range $table := .Tables}}{{if not (or $table.IsJoinTable $table.IsView) -}}
+    {{ $alias := $.Aliases.Table $table.Name -}}
+    {{$alias.UpPlural}} models.{{$alias.UpSingular}}Slice `json:"{{$table.Name}},omitempty"`
+    {{end}}{{end -}}{{/* range tables */}}
+}
+
+// Report describes what a Run inserted. It can be encoded to JSON.
+type Report struct {
+	// Seed is the seed the Run used. Setting it on a Seeder inserts the same rows again
+	Seed int64 `json:"seed"`
+	// Duration is how long the Run took
+	Duration time.Duration `json:"duration"`
+	// Tables has the counts and timings of every table that was seeded, by name
+	Tables map[string]*TableReport `json:"tables"`
+	// Rows are the rows inserted into each table, fixtures first
+	Rows TableRows `json:"rows"`
+
+	mu sync.Mutex
+}
+
+// TableReport describes what was inserted into a table
+type TableReport struct {
+	// Rows is the number of rows inserted, fixtures included
+	Rows int `json:"rows"`
+	// Fixtures is the number of rows inserted from the fixtures
+	Fixtures int `json:"fixtures,omitempty"`
+	// Links is the number of links added to a join table
+	Links int `json:"links,omitempty"`
+	// Duration is how long the random rows took to seed,
+	// without the time spent waiting for other tables
+	Duration time.Duration `json:"duration"`
+}
+
+// table returns the report of a table, adding it if it is not there yet.
+// It can be called from the goroutines of concurrently seeded tables.
+func (r *Report) table(name string) *TableReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Tables[name] == nil {
+		r.Tables[name] = &TableReport{}
+	}
+
+	return r.Tables[name]
+}
+
+// Run seeds every table and returns a report of the rows it inserted.
+// The report is returned even if seeding fails, but it leaves out the rows of the tables that failed.
+func (s Seeder) Run(ctx context.Context, exec boil.ContextExecutor) (*Report, error) {
+	tables := make(map[string]bool, len(requiredParents))
+	for table := range requiredParents {
+		tables[table] = true
+	}
+
+	return s.run(ctx, exec, tables)
+}
+
+// RunTables seeds only the given tables and the tables they cannot be seeded without,
+// i.e. the tables referenced by their NOT NULL foreign keys, and so on.
+// Every other table is left alone. The table names are the ones in models.TableNames.
+func (s Seeder) RunTables(ctx context.Context, exec boil.ContextExecutor, tables ...string) (*Report, error) {
+	selected := make(map[string]bool, len(tables))
+
+	pending := append([]string{}, tables...)
+	for len(pending) > 0 {
+		table := pending[0]
+		pending = pending[1:]
+
+		if selected[table] {
+			continue
+		}
+
+		parents, ok := requiredParents[table]
+		if !ok {
+			return nil, fmt.Errorf("unknown table %q", table)
+		}
+
+		selected[table] = true
+		pending = append(pending, parents...)
+	}
+
+	return s.run(ctx, exec, selected)
+}
+
+// run seeds the selected tables and reports the rows inserted
+func (s Seeder) run(ctx context.Context, exec boil.ContextExecutor, tables map[string]bool) (*Report, error) {
+	if s.Seed == 0 {
+		s.Seed = time.Now().UnixNano()
+	}
+
+	report := &Report{Seed: s.Seed, Tables: map[string]*TableReport{}}
+	started := time.Now()
+	err := s.seedTables(ctx, exec, tables, report)
+	report.Duration = time.Since(started)
+
+	{{range $table := .Tables}}{{if not (or $table.IsJoinTable $table.IsView) -}}
+	{{ $alias := $.Aliases.Table $table.Name -}}
+	if tables["{{$table.Name}}"] {
+		report.table("{{$table.Name}}").Rows = len(report.Rows.{{$alias.UpPlural}})
+	}
+	{{end}}{{end -}}{{/* range tables */}}
+
+	return report, err
+}
+
+// seedTables seeds the selected tables, adding the rows it inserts to the report
+func (s Seeder) seedTables(ctx context.Context, exec boil.ContextExecutor, tables map[string]bool, report *Report) error {
+	inserted := &report.Rows
+
+	var existing TableRows
+	if s.ExistingParentsRatio > 0 {
+		if err := s.loadExisting(ctx, exec, &existing); err != nil {
+			return err
+		}
+	}
+
+	// The fixtures are inserted first so that random rows can reference them,
+	// and they count towards the minimum number of rows of their table
+	var fixtures TableRows
+	if len(s.Fixtures) > 0 {
+		err := s.insertFixtures(ctx, exec, tables, &fixtures)
+		*inserted = fixtures
+		if err != nil {
+			return err
+		}
+
+		{{range $table := .Tables}}{{if not (or $table.IsJoinTable $table.IsView) -}}
+		{{ $alias := $.Aliases.Table $table.Name -}}
+		s.Min{{$alias.UpPlural}}ToSeed = remaining(s.Min{{$alias.UpPlural}}ToSeed, len(fixtures.{{$alias.UpPlural}}))
+		if n := len(fixtures.{{$alias.UpPlural}}); n > 0 {
+			report.table("{{$table.Name}}").Fixtures = n
+		}
+		{{end}}{{end -}}{{/* range tables */}}
+	}
+
+	var err error
+	if s.Sequential {
+		err = s.runSequential(ctx, exec, tables, report, &existing)
+	} else {
+		err = s.runConcurrent(ctx, exec, tables, report, &existing)
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	{{range $table := .Tables}}{{ $deferredFKeys := deferredFKeys $.Tables $table }}{{if $deferredFKeys -}}
+	{{ $alias := $.Aliases.Table $table.Name -}}
+	// the foreign keys of the fixtures are left as they are
+	if err := s.backfill{{$alias.UpPlural}}(ctx, exec, inserted.{{$alias.UpPlural}}[len(fixtures.{{$alias.UpPlural}}):]{{range $deferredFKeys}}{{ $ftable := $.Aliases.Table .ForeignTable }}, inserted.{{$ftable.UpPlural}}{{end}}); err != nil {
+		errs = append(errs, err)
+	}
+	{{end}}{{end -}}{{/* range tables */}}
+
+	return errors.Join(errs...)
+}
+
+// insertFixture inserts a fixture row into the table and adds it to rows
+func insertFixture(ctx context.Context, exec boil.ContextExecutor, table string, values map[string]interface{}, rows *TableRows) (interface{}, error) {
+	switch table {
+	{{range $table := .Tables}}{{if not (or $table.IsJoinTable $table.IsView) -}}
+	{{ $alias := $.Aliases.Table $table.Name -}}
+	case "{{$table.Name}}":
+		o, err := insert{{$alias.UpSingular}}Fixture(ctx, exec, values)
+		if err != nil {
+			return nil, err
+		}
+		rows.{{$alias.UpPlural}} = append(rows.{{$alias.UpPlural}}, o)
+		return o, nil
+	{{end}}{{end -}}{{/* range tables */}}
+	}
+
+	return nil, fmt.Errorf("fixtures cannot be inserted into %s", table)
+}
+
+// fixtureReferences lists the foreign key columns of each table,
+// the table they reference and every column of their constraint
+var fixtureReferences = map[string]map[string]fixtureReference{
+	{{range $table := .Tables}}{{if not (or $table.IsJoinTable $table.IsView) -}}
+	"{{$table.Name}}": {
+		{{range fixtureRefs $table -}}
+		"{{.Column}}": {"{{.ForeignTable}}", map[string]string{ {{- range .Parts}}"{{.Column}}": "{{.ForeignColumn}}", {{end -}} }},
+		{{end -}}
+	},
+	{{end}}{{end -}}{{/* range tables */}}
+}
+
+// loadExisting loads the rows that are already in the tables other tables reference,
+// composite foreign keys included, so that relationships can be drawn from them
+func (s Seeder) loadExisting(ctx context.Context, exec boil.ContextExecutor, existing *TableRows) error {
+	var err error
+
+	{{range $table := .Tables}}{{if and (not (or $table.IsJoinTable $table.IsView)) (isReferenced $.Tables $table) -}}
+	{{ $alias := $.Aliases.Table $table.Name -}}
+	existing.{{$alias.UpPlural}}, err = models.{{$alias.UpPlural}}().All({{if not $.NoContext}}ctx, {{end}}exec)
+	if err != nil {
+		return tableError("{{$table.Name}}", fmt.Errorf("error getting existing {{$alias.DownPlural}}: %w", err))
+	}
+
+	{{end}}{{end -}}{{/* range tables */}}
+	return nil
+}
+
+// RunInTx seeds the database in a single transaction.
+// The tables are seeded sequentially since statements cannot be run
+// concurrently on a transaction. If seeding fails, the transaction is rolled back,
+// the database is left untouched and no report is returned.
+func (s Seeder) RunInTx(ctx context.Context, db boil.ContextBeginner) (*Report, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+
+	s.Sequential = true
+	s.stopAtFirstError = true
+	report, err := s.Run(ctx, tx)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+
+	return report, nil
+}
+
+// requiredParents lists the tables that each table cannot be seeded without
+var requiredParents = map[string][]string{
+	{{range $table := .Tables}}{{if not $table.IsView -}}
+	"{{$table.Name}}": { {{- range $table.FKeys}}{{if and (ne .ForeignTable $table.Name) (or $table.IsJoinTable (not .Nullable))}}"{{.ForeignTable}}", {{end}}{{end -}} },
+	{{end}}{{end -}}{{/* range tables */}}
+}
+
+// runSequential seeds the selected tables one after the other on a single goroutine.
+// Every table is seeded after the tables it references, and is skipped
+// if one of them failed or ctx is done. The errors of every failed table are returned,
+// except in RunInTx, which stops at the first table that fails.
+func (s Seeder) runSequential(ctx context.Context, exec boil.ContextExecutor, tables map[string]bool, report *Report, existing *TableRows) error {
+	inserted := &report.Rows
+	var failed sync.Map
+	var errs []error
+
+	{{range $table := seedOrder .Tables -}}
+	{{ $alias := $.Aliases.Table $table.Name -}}
+	if tables["{{$table.Name}}"] {
+		skip := ctx.Err() != nil
+		{{- range parentFKeys $.Tables $table}}
+		{{- if .Nullable}}{{ $relAlias := $alias.Relationship .Name }} || (s.{{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio < 1 && anyFailed(&failed, "{{.ForeignTable}}"))
+		{{- else}} || anyFailed(&failed, "{{.ForeignTable}}")
+		{{- end}}{{end}}
+		if skip {
+			failed.Store("{{$table.Name}}", true)
+		} else {
+			started := time.Now()
+			{{if not $table.IsJoinTable -}}
+			rows, err := s.seed{{$alias.UpPlural}}(ctx, exec{{range parentFKeys $.Tables $table}}{{ $ftable := $.Aliases.Table .ForeignTable }}, mix{{$ftable.UpPlural}}(inserted.{{$ftable.UpPlural}}, existing.{{$ftable.UpPlural}}, s.ExistingParentsRatio){{end}})
+			inserted.{{$alias.UpPlural}} = append(inserted.{{$alias.UpPlural}}, rows...)
+			report.table("{{$table.Name}}").Duration = time.Since(started)
+			{{- else -}}
+			links, err := s.seed{{titleCase $table.Name}}(ctx, exec{{range $table.FKeys}}{{ $ftable := $.Aliases.Table .ForeignTable }}, mix{{$ftable.UpPlural}}(inserted.{{$ftable.UpPlural}}, existing.{{$ftable.UpPlural}}, s.ExistingParentsRatio){{end}})
+			table := report.table("{{$table.Name}}")
+			table.Links, table.Duration = links, time.Since(started)
+			{{- end}}
+			if err != nil {
+				if s.stopAtFirstError {
+					return tableError("{{$table.Name}}", err)
+				}
+				failed.Store("{{$table.Name}}", true)
+				errs = append(errs, tableError("{{$table.Name}}", err))
+			}
+		}
+	}
+
+	{{end -}}{{/* range tables */}}
+
+	// tables that were skipped because ctx is done have no error of their own,
+	// and the drivers do not always wrap ctx.Err() in the error of an interrupted insert
+	if err := ctx.Err(); err != nil && !errors.Is(errors.Join(errs...), err) {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// runConcurrent seeds every selected table on its own goroutine.
+// Each table waits for the tables it references to be seeded,
+// and is skipped if one of them failed or ctx is done. The errors of every
+// failed table are returned.
+{{- if not .NoHooks}}
+// The first error of a hook stops every table, and the errors of the tables
+// that only failed because they were stopped are left out.
+{{- end}}
+func (s Seeder) runConcurrent(ctx context.Context, exec boil.ContextExecutor, tables map[string]bool, report *Report, existing *TableRows) error {
+	inserted := &report.Rows
 	var wg sync.WaitGroup
+	var failed sync.Map
+
+	// runCtx is cancelled to stop the tables that are still seeding
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
 
-	ctxMain, cancelMain := context.WithCancel(ctx)
-	defer cancelMain()
-	
+	// each channel is closed once its table is done, after its rows are added to inserted,
+	// so the tables waiting for it read the rows without racing with the append
 	{{range $table := .Tables}}{{if $table.IsJoinTable -}}
-	ctx{{titleCase $table.Name}}, cancel{{titleCase $table.Name}} := context.WithCancel(ctxMain)
+	done{{titleCase $table.Name}} := make(chan struct{})
 	{{else if not $table.IsView -}}
 	{{ $alias := $.Aliases.Table $table.Name -}}
-	ctx{{$alias.UpPlural}}, cancel{{$alias.UpPlural}} := context.WithCancel(ctxMain)
+	done{{$alias.UpPlural}} := make(chan struct{})
 	{{end}}{{end -}}{{/* range tables */}}
 
     errChan := make(chan error, {{len .Tables}})
@@ -56,36 +1004,107 @@ func (s Seeder) Run(ctx context.Context, exec boil.ContextExecutor) error {
 	// Run{{$alias.UpPlural}}Seed()
 	wg.Add(1)
 	go func() {
-		defer cancel{{if not $table.IsJoinTable -}}{{$alias.UpPlural}}{{else}}{{titleCase $table.Name}}{{end}}()
 		defer wg.Done()
-		{{range $table.FKeys -}}
+		defer close(done{{if not $table.IsJoinTable -}}{{$alias.UpPlural}}{{else}}{{titleCase $table.Name}}{{end}})
+		if !tables["{{$table.Name}}"] {
+			return
+		}
+
+		{{range parentFKeys $.Tables $table -}}
 		{{ $ftable := $.Aliases.Table .ForeignTable -}}
-		<-ctx{{$ftable.UpPlural}}.Done()
+		{{if .Nullable -}}
+		{{- $relAlias := $alias.Relationship .Name -}}
+		// {{.Column}} can be NULL, {{$table.Name}} only wait for {{.ForeignTable}} if some rows point to it
+		var {{$ftable.DownPlural}} models.{{$ftable.UpSingular}}Slice
+		if s.{{$alias.UpSingular}}{{$relAlias.Foreign}}NullRatio < 1 {
+			<-done{{$ftable.UpPlural}}
+			if anyFailed(&failed, "{{.ForeignTable}}") {
+				failed.Store("{{$table.Name}}", true)
+				return
+			}
+			{{$ftable.DownPlural}} = mix{{$ftable.UpPlural}}(inserted.{{$ftable.UpPlural}}, existing.{{$ftable.UpPlural}}, s.ExistingParentsRatio)
+		}
+		{{else -}}
+		<-done{{$ftable.UpPlural}}
+		{{end -}}
 		{{end}}
-		{{if not $table.IsJoinTable -}}
-		if err := s.seed{{$alias.UpPlural}}(ctx{{$alias.UpPlural}}, exec); err != nil {
-			errChan <- err
-			cancelMain()
+		{{- if parentFKeys $.Tables $table}}
+		// the run was cancelled or stopped while waiting
+		if runCtx.Err() != nil {
+			failed.Store("{{$table.Name}}", true)
+			return
 		}
-		{{else}}
-		if err := s.seed{{titleCase $table.Name}}(ctx{{titleCase $table.Name}}, exec); err != nil {
-			errChan <- err
-			cancelMain()
+		{{end}}
+		{{- $required := false}}{{range parentFKeys $.Tables $table}}{{if not .Nullable}}{{$required = true}}{{end}}{{end}}
+		{{- if $required}}
+		if anyFailed(&failed{{range parentFKeys $.Tables $table}}{{if not .Nullable}}, "{{.ForeignTable}}"{{end}}{{end}}) {
+			failed.Store("{{$table.Name}}", true)
+			return
+		}
+		{{end}}
+		started := time.Now()
+		{{if not $table.IsJoinTable -}}
+		rows, err := s.seed{{$alias.UpPlural}}(runCtx, exec{{range parentFKeys $.Tables $table}}{{ $ftable := $.Aliases.Table .ForeignTable }}, {{if .Nullable}}{{$ftable.DownPlural}}{{else}}mix{{$ftable.UpPlural}}(inserted.{{$ftable.UpPlural}}, existing.{{$ftable.UpPlural}}, s.ExistingParentsRatio){{end}}{{end}})
+		inserted.{{$alias.UpPlural}} = append(inserted.{{$alias.UpPlural}}, rows...)
+		report.table("{{$table.Name}}").Duration = time.Since(started)
+		{{- else -}}
+		links, err := s.seed{{titleCase $table.Name}}(runCtx, exec{{range $table.FKeys}}{{ $ftable := $.Aliases.Table .ForeignTable }}, mix{{$ftable.UpPlural}}(inserted.{{$ftable.UpPlural}}, existing.{{$ftable.UpPlural}}, s.ExistingParentsRatio){{end}})
+		table := report.table("{{$table.Name}}")
+		table.Links, table.Duration = links, time.Since(started)
+		{{- end}}
+		if err != nil {
+			failed.Store("{{$table.Name}}", true)
+			errChan <- tableError("{{$table.Name}}", err)
+			{{- if not $.NoHooks}}
+			var hookErr *hookError
+			if errors.As(err, &hookErr) {
+				stop()
+			}
+			{{- end}}
 		}
-		{{- end -}}
 	}()
 	{{end}}{{/* range not IsView */}}
 	{{end}}{{/* range tables */}}
 
 	wg.Wait()
-
 	close(errChan)
-	err := <-errChan
-	if err != nil {
-		return err
+
+	var errs []error
+	for err := range errChan {
+		{{- if not .NoHooks}}
+		// the tables stopped by the error of a hook only report that runCtx was cancelled
+		var hookErr *hookError
+		if ctx.Err() == nil && runCtx.Err() != nil && errors.Is(err, context.Canceled) && !errors.As(err, &hookErr) {
+			continue
+		}
+		{{- end}}
+		errs = append(errs, err)
 	}
 
-	return nil
+	// tables that were skipped because ctx is done have no error of their own,
+	// and the drivers do not always wrap ctx.Err() in the error of an interrupted insert
+	if err := ctx.Err(); err != nil && !errors.Is(errors.Join(errs...), err) {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// leaveNull reports whether the optional foreign key of row i is left NULL.
+// The NULL rows are spread evenly, so that ratio of the rows are NULL.
+func leaveNull(i int, ratio float64) bool {
+	return math.Floor(float64(i+1)*ratio) > math.Floor(float64(i)*ratio)
+}
+
+// anyFailed reports whether seeding any of the tables failed
+func anyFailed(failed *sync.Map, tables ...string) bool {
+	for _, table := range tables {
+		if _, ok := failed.Load(table); ok {
+			return true
+		}
+	}
+
+	return false
 }
 
 {{range $table := .Tables}}{{if $table.IsJoinTable -}}
@@ -97,81 +1116,81 @@ func (s Seeder) Run(ctx context.Context, exec boil.ContextExecutor) error {
 {{ $alias1 := $.Aliases.Table $fkey1.ForeignTable -}}
 {{ $relAlias0 := $alias.Relationship $fkey0.Name -}}
 {{ $relAlias1 := $alias.Relationship $fkey1.Name -}}
-func (s Seeder) seed{{titleCase $table.Name}}(ctx context.Context, exec boil.ContextExecutor) error {
-	fmt.Println("Adding {{titleCase $table.Name}}")
-	NoOfRels := s.MinRelsPer{{titleCase $table.Name}}
-
-	{{range $table.FKeys -}}
-	{{ $ftable := $.Aliases.Table .ForeignTable -}}
-	{{$ftable.DownPlural}}, err := models.{{$ftable.UpPlural}}().All({{if not $.NoContext}}ctx, {{end}}exec)
-	if err != nil {
-		return fmt.Errorf("error getting {{$ftable.DownPlural}}: %w", err)
-	}
-
-	if NoOfRels < len({{$ftable.DownPlural}}) {
-		NoOfRels = len({{$ftable.DownPlural}})
-	}
+// seed{{titleCase $table.Name}} links the given {{$alias0.UpPlural}} and {{$alias1.UpPlural}}
+// and returns the number of links it added
+func (s Seeder) seed{{titleCase $table.Name}}(ctx context.Context, exec boil.ContextExecutor, {{$alias0.DownPlural}} models.{{$alias0.UpSingular}}Slice, {{$alias1.DownPlural}} models.{{$alias1.UpSingular}}Slice) (int, error) {
+	s.log("seeding table", "table", "{{$table.Name}}")
+	var errs []error
+	r := s.randFor("{{$table.Name}}")
 
-	{{end}}
+	links := planLinks(r, len({{$alias0.DownPlural}}), len({{$alias1.DownPlural}}), linkPlan{
+		minRels: s.MinRelsPer{{titleCase $table.Name}},
+		min0:    s.{{$relAlias0.Local}}Per{{$alias0.UpSingular}}Min,
+		max0:    s.{{$relAlias0.Local}}Per{{$alias0.UpSingular}}Max,
+		min1:    s.{{$relAlias1.Local}}Per{{$alias1.UpSingular}}Min,
+		max1:    s.{{$relAlias1.Local}}Per{{$alias1.UpSingular}}Max,
+		count:   s.{{titleCase $table.Name}}Count,
+		density: s.{{titleCase $table.Name}}Density,
+		skew:    s.{{titleCase $table.Name}}Skew,
+	})
 
-  // Seed from the lesser one
-	switch {
-	case len({{$alias0.DownPlural}}) <  len({{$alias1.DownPlural}}):
-		for i := 0; i < len({{$alias0.DownPlural}}); i++ {
-			o := {{$alias0.DownPlural}}[i]
-
-			relatedIndexes := map[int]struct{}{}
-			related := models.{{$alias1.UpSingular}}Slice{}
-
-			for i := 0; i < NoOfRels; i++ {
-				index := rand.Int() % len({{$alias1.DownPlural}})
-				_, alreadyIn := relatedIndexes[index]
-				retries := 0
-				
-				for alreadyIn && retries < s.Retries {
-					retries++
-					index = rand.Int() % len({{$alias1.DownPlural}})
-					 _, alreadyIn = relatedIndexes[index]
-				}
+	// Rows that were in the database before the Run can be linked already,
+	// and appear more than once when they are mixed in with ExistingParentsRatio
+	linked := seenSet{}
+	if s.ExistingParentsRatio > 0 {
+		rows, err := exec.QueryContext(ctx, "SELECT {{$.LQ}}{{$fkey0.Column}}{{$.RQ}}, {{$.LQ}}{{$fkey1.Column}}{{$.RQ}} FROM {{$table.Name | $.SchemaTable}}")
+		if err != nil {
+			return 0, tableError("{{$table.Name}}", fmt.Errorf("error getting existing links: %w", err))
+		}
+		defer rows.Close()
 
-        if !alreadyIn {
-            relatedIndexes[index] = struct{}{}
-            related = append(related, {{$alias1.DownPlural}}[index])
-        }
+		for rows.Next() {
+			var id0 {{($table.GetColumn $fkey0.Column).Type}}
+			var id1 {{($table.GetColumn $fkey1.Column).Type}}
+			if err := rows.Scan(&id0, &id1); err != nil {
+				return 0, tableError("{{$table.Name}}", fmt.Errorf("error getting existing links: %w", err))
 			}
-
-			o.Add{{$relAlias0.Local}}({{if not $.NoContext}}ctx, {{end}}exec, false, related...)
+			linked.add([]interface{}{id0, id1})
 		}
+		if err := rows.Err(); err != nil {
+			return 0, tableError("{{$table.Name}}", fmt.Errorf("error getting existing links: %w", err))
+		}
+	}
 
-	case  len({{$alias1.DownPlural}}) <= len({{$alias0.DownPlural}}):
-		for i := 0; i < len({{$alias1.DownPlural}}); i++ {
-			o := {{$alias1.DownPlural}}[i]
+	total, done, added := 0, 0, 0
+	for _, related := range links {
+		total += len(related)
+	}
 
-			relatedIndexes := map[int]struct{}{}
-			related := models.{{$alias0.UpSingular}}Slice{}
+	for i, o := range {{$alias0.DownPlural}} {
+		related := make(models.{{$alias1.UpSingular}}Slice, 0, len(links[i]))
+		for _, j := range links[i] {
+			link := []interface{}{o.{{$alias0.Column $fkey0.ForeignColumn}}, {{$alias1.DownPlural}}[j].{{$alias1.Column $fkey1.ForeignColumn}}}
+			if linked.has(link) {
+				continue
+			}
 
-			for i := 0; i < NoOfRels; i++ {
-				index := rand.Int() % len({{$alias0.DownPlural}})
-				_, alreadyIn := relatedIndexes[index]
-				retries := 0
-				
-				for alreadyIn && retries < s.Retries {
-					retries++
-					index = rand.Int() % len({{$alias0.DownPlural}})
-					 _, alreadyIn = relatedIndexes[index]
-				}
+			linked.add(link)
+			related = append(related, {{$alias1.DownPlural}}[j])
+		}
 
-        if !alreadyIn {
-            relatedIndexes[index] = struct{}{}
-            related = append(related, {{$alias0.DownPlural}}[index])
-        }
-			}
+		if len(related) == 0 {
+			continue
+		}
 
-			o.Add{{$relAlias1.Local}}({{if not $.NoContext}}ctx, {{end}}exec, false, related...)
+		if err := o.Add{{$relAlias0.Local}}({{if not $.NoContext}}ctx, {{end}}exec, false, related...); err != nil {
+			errs = append(errs, &SeedError{Table: "{{$table.Name}}", Row: i, Err: err})
+		} else {
+			added += len(related)
 		}
+
+		// links that were already there are skipped, but still count towards the total
+		done += len(links[i])
+		s.progress("{{$table.Name}}", done, total)
 	}
 
-	return nil
+	s.log("seeded table", "table", "{{$table.Name}}", "links", added)
+	return added, errors.Join(errs...)
 }
 {{end}}{{end -}}{{/* range tables */}}
 
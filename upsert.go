@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+// upsertTables maps the tables that are seeded with Upsert to the column
+// a conflict is detected on, or to an empty string for the primary key.
+// It is read from the --upsert-tables flag or the boilingseed.upsert_tables config list.
+var upsertTables map[string]string
+
+// parseUpsertTables reads the upsert tables, each given as table or table:column
+func parseUpsertTables(entries []string) (map[string]string, error) {
+	upserts := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		table, column, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		if table == "" {
+			return nil, fmt.Errorf("upsert table %q has no table name", entry)
+		}
+		if _, ok := upserts[table]; ok {
+			return nil, fmt.Errorf("upsert table %s is listed more than once", table)
+		}
+
+		upserts[table] = column
+	}
+
+	return upserts, nil
+}
+
+// checkUpsertTables makes sure that the upsert tables can be upserted:
+// they are tables with a model, and their rows conflict on the primary key
+// or on a column with a single-column UNIQUE constraint
+func checkUpsertTables(tables []drivers.Table, upserts map[string]string) error {
+	for name, column := range upserts {
+		i := tableIndex(tables, name)
+		if i < 0 {
+			return fmt.Errorf("upsert table %s: unknown table", name)
+		}
+
+		table := tables[i]
+		if table.IsView || table.IsJoinTable {
+			return fmt.Errorf("upsert table %s: views and join tables cannot be upserted", name)
+		}
+
+		if column == "" {
+			if table.PKey == nil {
+				return fmt.Errorf("upsert table %s: the table has no primary key, name a UNIQUE column with %s:column", name, name)
+			}
+			continue
+		}
+
+		col, ok := findColumn(table, column)
+		if !ok {
+			return fmt.Errorf("upsert table %s: unknown column %s", name, column)
+		}
+
+		isPKey := table.PKey != nil && len(table.PKey.Columns) == 1 && table.PKey.Columns[0] == column
+		if !col.Unique && !isPKey {
+			return fmt.Errorf("upsert table %s: column %s is not UNIQUE", name, column)
+		}
+	}
+
+	return nil
+}
+
+// isUpsert reports whether the rows of the table are inserted with Upsert
+func isUpsert(table drivers.Table) bool {
+	_, ok := upsertTables[table.Name]
+	return ok
+}
+
+// upsertArgs returns the arguments passed to the Upsert method of the table's model
+// after the executor. They differ between drivers, and only some of them
+// take the columns a conflict is detected on; the others detect it on any unique key.
+func upsertArgs(driverName string, table drivers.Table) string {
+	switch driverName {
+	case "mysql", "mssql":
+		return "boil.Infer(), boil.Infer()"
+	}
+
+	conflict := "nil"
+	if column := upsertTables[table.Name]; column != "" {
+		conflict = fmt.Sprintf("[]string{%q}", column)
+	}
+
+	return "true, " + conflict + ", boil.Infer(), boil.Infer()"
+}
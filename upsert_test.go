@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/aarondl/sqlboiler/v4/drivers"
+)
+
+func TestParseUpsertTables(t *testing.T) {
+	upserts, err := parseUpsertTables([]string{"countries", " users:email "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(upserts) != 2 || upserts["countries"] != "" || upserts["users"] != "email" {
+		t.Errorf("unexpected upsert tables: %v", upserts)
+	}
+
+	for _, entries := range [][]string{{":email"}, {"users", "users:email"}} {
+		if _, err := parseUpsertTables(entries); err == nil {
+			t.Errorf("expected an error for %v", entries)
+		}
+	}
+}
+
+func TestCheckUpsertTables(t *testing.T) {
+	users := table("users")
+	users.Columns = []drivers.Column{{Name: "id"}, {Name: "email", Unique: true}, {Name: "name"}}
+	logs := drivers.Table{Name: "logs", Columns: []drivers.Column{{Name: "message"}}}
+	userRoles := table("user_roles")
+	userRoles.IsJoinTable = true
+	tables := []drivers.Table{users, logs, userRoles}
+
+	tests := []struct {
+		name    string
+		upserts map[string]string
+		err     string
+	}{
+		{name: "primary key", upserts: map[string]string{"users": ""}},
+		{name: "unique column", upserts: map[string]string{"users": "email"}},
+		{name: "primary key column", upserts: map[string]string{"users": "id"}},
+		{name: "column that is not unique", upserts: map[string]string{"users": "name"}, err: "not UNIQUE"},
+		{name: "unknown column", upserts: map[string]string{"users": "phone"}, err: "unknown column"},
+		{name: "unknown table", upserts: map[string]string{"accounts": ""}, err: "unknown table"},
+		{name: "no primary key", upserts: map[string]string{"logs": ""}, err: "no primary key"},
+		{name: "join table", upserts: map[string]string{"user_roles": ""}, err: "join tables"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUpsertTables(tables, tt.upserts)
+			if tt.err == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestUpsertArgs(t *testing.T) {
+	upsertTables = map[string]string{"users": "email", "countries": ""}
+	defer func() { upsertTables = nil }()
+
+	tests := []struct {
+		driver   string
+		table    string
+		expected string
+	}{
+		{"psql", "users", `true, []string{"email"}, boil.Infer(), boil.Infer()`},
+		{"sqlite3", "countries", "true, nil, boil.Infer(), boil.Infer()"},
+		{"mysql", "users", "boil.Infer(), boil.Infer()"},
+		{"mssql", "users", "boil.Infer(), boil.Infer()"},
+	}
+
+	for _, tt := range tests {
+		if args := upsertArgs(tt.driver, table(tt.table)); args != tt.expected {
+			t.Errorf("%s %s: expected %s, got %s", tt.driver, tt.table, tt.expected, args)
+		}
+	}
+
+	if !isUpsert(table("users")) || isUpsert(table("orders")) {
+		t.Error("expected only the listed tables to be upserted")
+	}
+}
+
+// upsertCallSource is the Upsert call of the generated seeder, with stand-ins
+// for the model and the boil package. %s is the signature of Upsert after the
+// executor and %s the arguments given to it.
+const upsertCallSource = `package seeds
+
+type columns struct{}
+
+type boilPackage struct{}
+
+func (boilPackage) Infer() columns { return columns{} }
+
+var boil boilPackage
+
+type User struct{}
+
+func (o *User) Upsert(ctx, exec interface{}, %s) error { return nil }
+
+func upsertUser(ctx, exec interface{}, o *User) error {
+	return o.Upsert(ctx, exec, %s)
+}
+`
+
+func TestUpsertArgsCompile(t *testing.T) {
+	upsertTables = map[string]string{"users": "email"}
+	defer func() { upsertTables = nil }()
+
+	// the signatures of the Upsert methods generated by sqlboiler for each driver
+	signatures := map[string]string{
+		"psql":    "updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns columns, opts ...func()",
+		"sqlite3": "updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns columns",
+		"mysql":   "updateColumns, insertColumns columns",
+		"mssql":   "updateColumns, insertColumns columns",
+	}
+
+	for driver, signature := range signatures {
+		t.Run(driver, func(t *testing.T) {
+			src := fmt.Sprintf(upsertCallSource, signature, upsertArgs(driver, table("users")))
+
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "upsert.go", src, 0)
+			if err != nil {
+				t.Fatalf("unable to parse the upsert call: %v", err)
+			}
+
+			var conf types.Config
+			if _, err := conf.Check("seeds", fset, []*ast.File{file}, nil); err != nil {
+				t.Errorf("the upsert call does not compile: %v", err)
+			}
+		})
+	}
+}